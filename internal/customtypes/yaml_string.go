@@ -0,0 +1,206 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customtypes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLMetadataKey is the top-level key of the metadata section of a YAML
+// document.
+const YAMLMetadataKey = "metadata"
+
+// YAMLIgnoredMetadataKeys are the keys of the metadata section that are
+// ignored when comparing YAML documents, since they are injected into the
+// document by the API. Any other metadata keys are compared.
+var YAMLIgnoredMetadataKeys = []string{"name", "guidelines", "category", "severity"}
+
+var (
+	_ basetypes.StringTypable                    = (*YAMLStringType)(nil)
+	_ basetypes.StringValuableWithSemanticEquals = (*YAMLStringValue)(nil)
+	_ xattr.ValidateableAttribute                = (*YAMLStringValue)(nil)
+)
+
+// YAMLStringType is a String type for attributes that contain a YAML
+// document.
+type YAMLStringType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t YAMLStringType) String() string {
+	return "customtypes.YAMLStringType"
+}
+
+// ValueType returns the Value type.
+func (t YAMLStringType) ValueType(ctx context.Context) attr.Value {
+	return YAMLStringValue{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t YAMLStringType) Equal(o attr.Type) bool {
+	other, ok := o.(YAMLStringType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t YAMLStringType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return YAMLStringValue{
+		StringValue: in,
+	}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t YAMLStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// YAMLStringValue is a String value containing a YAML document. Two values
+// are semantically equal if they contain the same YAML document, regardless
+// of key order, quoting, whitespace or the metadata keys in
+// YAMLIgnoredMetadataKeys.
+type YAMLStringValue struct {
+	basetypes.StringValue
+}
+
+// NewYAMLStringValue creates a known YAMLStringValue.
+func NewYAMLStringValue(value string) YAMLStringValue {
+	return YAMLStringValue{
+		StringValue: basetypes.NewStringValue(value),
+	}
+}
+
+// NewYAMLStringNull creates a null YAMLStringValue.
+func NewYAMLStringNull() YAMLStringValue {
+	return YAMLStringValue{
+		StringValue: basetypes.NewStringNull(),
+	}
+}
+
+// NewYAMLStringUnknown creates an unknown YAMLStringValue.
+func NewYAMLStringUnknown() YAMLStringValue {
+	return YAMLStringValue{
+		StringValue: basetypes.NewStringUnknown(),
+	}
+}
+
+// Type returns a YAMLStringType.
+func (v YAMLStringValue) Type(ctx context.Context) attr.Type {
+	return YAMLStringType{}
+}
+
+// Equal returns true if the given value is equivalent.
+func (v YAMLStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(YAMLStringValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if the given value contains the same
+// YAML document, ignoring the metadata keys in YAMLIgnoredMetadataKeys.
+func (v YAMLStringValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(YAMLStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	return yamlDocumentsEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// ValidateAttribute reports an error if the value is not a valid YAML
+// document.
+func (v YAMLStringValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	var document any
+	if err := yaml.Unmarshal([]byte(v.ValueString()), &document); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid YAML String Value",
+			"A string value was provided that is not valid YAML.\n\n"+
+				"Given Value: "+v.ValueString()+"\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+// yamlDocumentsEqual returns true if both YAML documents are mappings that
+// are equal after removing the metadata keys in YAMLIgnoredMetadataKeys,
+// and the metadata section itself if no other keys remain. Documents
+// that cannot be parsed or are not mappings, including empty documents, are
+// never equal.
+func yamlDocumentsEqual(a, b string) bool {
+	var aMap, bMap map[string]any
+	if err := yaml.Unmarshal([]byte(a), &aMap); err != nil || aMap == nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(b), &bMap); err != nil || bMap == nil {
+		return false
+	}
+
+	removeIgnoredMetadata(aMap)
+	removeIgnoredMetadata(bMap)
+
+	return reflect.DeepEqual(aMap, bMap)
+}
+
+// removeIgnoredMetadata removes the keys in YAMLIgnoredMetadataKeys from the
+// metadata section of document, and the section itself if it is then empty.
+func removeIgnoredMetadata(document map[string]any) {
+	metadata, ok := document[YAMLMetadataKey].(map[string]any)
+	if !ok {
+		return
+	}
+
+	for _, key := range YAMLIgnoredMetadataKeys {
+		delete(metadata, key)
+	}
+
+	if len(metadata) == 0 {
+		delete(document, YAMLMetadataKey)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package customtypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestYAMLDocumentsEqual(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		"identical": {
+			a:        "scope:\n  provider: aws\n",
+			b:        "scope:\n  provider: aws\n",
+			expected: true,
+		},
+		"key-order-and-whitespace": {
+			a:        "scope:\n  provider: aws\ndefinition:\n  and: []\n",
+			b:        "definition: {and: []}\nscope:    {provider: aws}\n",
+			expected: true,
+		},
+		"quoting": {
+			a:        "scope:\n  provider: aws\n",
+			b:        "scope:\n  provider: \"aws\"\n",
+			expected: true,
+		},
+		"ignored-metadata": {
+			a:        "scope:\n  provider: aws\n",
+			b:        "metadata:\n  name: rule\nscope:\n  provider: aws\n",
+			expected: true,
+		},
+		"ignored-metadata-with-user-keys": {
+			a:        "metadata:\n  id: CUSTOM_1\nscope:\n  provider: aws\n",
+			b:        "metadata:\n  id: CUSTOM_1\n  name: rule\n  severity: high\nscope:\n  provider: aws\n",
+			expected: true,
+		},
+		"user-metadata-differs": {
+			a:        "metadata:\n  id: CUSTOM_1\nscope:\n  provider: aws\n",
+			b:        "metadata:\n  id: CUSTOM_2\n  name: rule\nscope:\n  provider: aws\n",
+			expected: false,
+		},
+		"user-metadata-removed": {
+			a:        "metadata:\n  id: CUSTOM_1\nscope:\n  provider: aws\n",
+			b:        "metadata:\n  name: rule\nscope:\n  provider: aws\n",
+			expected: false,
+		},
+		"different-value": {
+			a:        "scope:\n  provider: aws\n",
+			b:        "scope:\n  provider: azure\n",
+			expected: false,
+		},
+		"different-type": {
+			a:        "value: 10\n",
+			b:        "value: \"10\"\n",
+			expected: false,
+		},
+		"both-empty": {
+			a:        "",
+			b:        "",
+			expected: false,
+		},
+		"both-null": {
+			a:        "null",
+			b:        "~",
+			expected: false,
+		},
+		"both-scalars": {
+			a:        "rule",
+			b:        "rule",
+			expected: false,
+		},
+		"both-sequences": {
+			a:        "- rule\n",
+			b:        "- rule\n",
+			expected: false,
+		},
+		"invalid-yaml": {
+			a:        "scope: [aws",
+			b:        "scope: [aws",
+			expected: false,
+		},
+		"empty-mappings": {
+			a:        "{}",
+			b:        "metadata:\n  name: rule\n",
+			expected: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if equal := yamlDocumentsEqual(testCase.a, testCase.b); equal != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, equal)
+			}
+		})
+	}
+}
+
+func TestYAMLStringValueStringSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		current       YAMLStringValue
+		new           basetypes.StringValuable
+		expected      bool
+		expectedError bool
+	}{
+		"semantically-equal": {
+			current:  NewYAMLStringValue("scope:\n  provider: aws\n"),
+			new:      NewYAMLStringValue("metadata:\n  name: rule\nscope: {provider: aws}\n"),
+			expected: true,
+		},
+		"not-equal": {
+			current:  NewYAMLStringValue("scope:\n  provider: aws\n"),
+			new:      NewYAMLStringValue("scope:\n  provider: gcp\n"),
+			expected: false,
+		},
+		"wrong-type": {
+			current:       NewYAMLStringValue("scope:\n  provider: aws\n"),
+			new:           basetypes.NewStringValue("scope:\n  provider: aws\n"),
+			expected:      false,
+			expectedError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			equal, diags := testCase.current.StringSemanticEquals(context.Background(), testCase.new)
+			if diags.HasError() != testCase.expectedError {
+				t.Fatalf("expected error: %t, got %v", testCase.expectedError, diags)
+			}
+
+			if equal != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, equal)
+			}
+		})
+	}
+}
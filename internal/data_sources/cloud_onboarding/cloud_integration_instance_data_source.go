@@ -2,6 +2,8 @@ package cloudonboarding
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
 	"github.com/mdboynton/cortex-cloud-go/enums"
@@ -10,16 +12,20 @@ import (
 	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/cloud_onboarding"
 	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/util"
 	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource = &CloudIntegrationInstanceDataSource{}
+	_ datasource.DataSource                     = &CloudIntegrationInstanceDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &CloudIntegrationInstanceDataSource{}
 )
 
 // NewCloudIntegrationInstanceDataSource is a helper function to simplify the provider implementation.
@@ -43,8 +49,18 @@ func (r *CloudIntegrationInstanceDataSource) Schema(ctx context.Context, req dat
 		Description: "TODO",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "A unique identifier of the integration.",
-				Required:    true,
+				Description: "A unique identifier of the integration. " +
+					"This is also the tracking GUID of the integration " +
+					"template used to create the integration. Exactly one " +
+					"of `id`, `instance_name` or `account_id` must be " +
+					"configured.",
+				Optional: true,
+				Computed: true,
+			},
+			"account_id": schema.StringAttribute{
+				Description: "ID of the cloud account, subscription or " +
+					"project connected to the integration.",
+				Optional: true,
 			},
 			"additional_capabilities": schema.SingleNestedAttribute{
 				Description: "Define which additional security capabilities " +
@@ -93,28 +109,21 @@ func (r *CloudIntegrationInstanceDataSource) Schema(ctx context.Context, req dat
 									),
 								},
 							},
-							//"last_days": schema.Int32Attribute{
-							//	Description: "Number of days within which " +
-							//		"the tags on a registry image must have " +
-							//		"been created or updated for the image " +
-							//		"to be scanned. Minimum value is 0 and " +
-							//		"maximum value is 90. Cannot be " +
-							//		"configured if `type` is not set to " +
-							//		"`TAGS_MODIFIED_DAYS`.",
-							//	Optional: true,
-							//	Computed: true,
-							//	Validators: []validator.Int32{
-							//		int32validator.Between(0, 90),
-							//		int32validator.AlsoRequires(path.MatchRelative().AtParent().AtName("type")),
-							//	},
-							//},
+							"last_days": schema.Int32Attribute{
+								Description: "Number of days within which " +
+									"the tags on a registry image must have " +
+									"been created or updated for the image " +
+									"to be scanned. Only populated if `type` " +
+									"is `TAGS_MODIFIED_DAYS`.",
+								Computed: true,
+							},
 						},
 					},
-					//"serverless_scanning": schema.BoolAttribute{
-					//	Description: "TODO",
-					//	Optional:    true,
-					//	Computed:    true,
-					//},
+					"serverless_scanning": schema.BoolAttribute{
+						Description: "Whether serverless scanning is enabled.",
+						Optional:    true,
+						Computed:    true,
+					},
 					"xsiam_analytics": schema.BoolAttribute{
 						Description: "Whether to enable XSIAM analytics to " +
 							"analyze your endpoint data to develop a " +
@@ -191,8 +200,7 @@ func (r *CloudIntegrationInstanceDataSource) Schema(ctx context.Context, req dat
 				},
 			},
 			"instance_name": schema.StringAttribute{
-				Description: "Name of the integration instance. If left " +
-					"empty, the name will be auto-populated.",
+				Description: "Name of the integration instance.",
 				Optional: true,
 				Computed: true,
 				Validators: []validator.String{
@@ -243,6 +251,18 @@ func (r *CloudIntegrationInstanceDataSource) Schema(ctx context.Context, req dat
 	}
 }
 
+// ConfigValidators returns the validators for the data source
+// configuration.
+func (r *CloudIntegrationInstanceDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("instance_name"),
+			path.MatchRoot("account_id"),
+		),
+	}
+}
+
 // Configure adds the provider-configured client to the data source.
 func (r *CloudIntegrationInstanceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured
@@ -271,6 +291,14 @@ func (r *CloudIntegrationInstanceDataSource) Read(ctx context.Context, req datas
 		return
 	}
 
+	// Find the integration ID if the integration is looked up by another key
+	if config.Id.IsNull() {
+		r.lookupInstanceId(ctx, &resp.Diagnostics, &config)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Retrieve integration details from API
 	request := config.ToGetRequest(ctx, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -296,3 +324,44 @@ func (r *CloudIntegrationInstanceDataSource) Read(ctx context.Context, req datas
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
+
+// lookupInstanceId sets the ID of the model to the ID of the only
+// integration matching the configured lookup key, adding an error if zero
+// or multiple integrations match.
+func (r *CloudIntegrationInstanceDataSource) lookupInstanceId(ctx context.Context, diagnostics *diag.Diagnostics, config *models.CloudIntegrationInstanceModel) {
+	request := config.ToListRequest(ctx, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.ListInstances(ctx, request)
+	if err != nil {
+		diagnostics.AddError(
+			"Cloud Integration Data Source Read Error",
+			err.Error(),
+		)
+		return
+	}
+
+	criteria := request.RequestData.FilterData.Filter.And[0]
+	switch len(response.Reply.Data) {
+	case 0:
+		diagnostics.AddError(
+			"Cloud Integration Not Found",
+			fmt.Sprintf("No cloud integration was found where %s is %q.", criteria.SearchField, criteria.SearchValue),
+		)
+	case 1:
+		config.Id = types.StringValue(response.Reply.Data[0].Id)
+	default:
+		ids := make([]string, 0, len(response.Reply.Data))
+		for _, instance := range response.Reply.Data {
+			ids = append(ids, instance.Id)
+		}
+
+		diagnostics.AddError(
+			"Multiple Cloud Integrations Found",
+			fmt.Sprintf("%d cloud integrations were found where %s is %q: %s\n\n"+
+				"Use the id argument to select one of them.", len(ids), criteria.SearchField, criteria.SearchValue, strings.Join(ids, ", ")),
+		)
+	}
+}
@@ -0,0 +1,229 @@
+package cloudonboarding
+
+import (
+	"context"
+
+	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
+
+	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/cloud_onboarding"
+	providerModels "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/provider"
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// listInstancesPageSize is the number of integration instances requested
+// from the API per page.
+const listInstancesPageSize = 100
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &CloudIntegrationInstancesDataSource{}
+)
+
+// NewCloudIntegrationInstancesDataSource is a helper function to simplify the provider implementation.
+func NewCloudIntegrationInstancesDataSource() datasource.DataSource {
+	return &CloudIntegrationInstancesDataSource{}
+}
+
+// CloudIntegrationInstancesDataSource is the data source implementation.
+type CloudIntegrationInstancesDataSource struct {
+	client *cloudonboarding.Client
+}
+
+// Metadata returns the data source type name.
+func (r *CloudIntegrationInstancesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_integration_instances"
+}
+
+// Schema defines the schema for the data source.
+func (r *CloudIntegrationInstancesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	criteriaAttributes := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"search_field": schema.StringAttribute{
+				Description: "Field to filter on. Must be one of `ID`, " +
+					"`INSTANCE_NAME`, `CLOUD_TYPE`, `STATUS`, `SCAN_MODE`, " +
+					"`SCOPE`, `ACCOUNT_NAME` or `ACCOUNT_ID`.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(models.AllIntegrationSearchFields()...),
+				},
+			},
+			"search_type": schema.StringAttribute{
+				Description: "Comparison operator. Must be one of `EQ`, " +
+					"`NEQ`, `CONTAINS` or `NCONTAINS`.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("EQ", "NEQ", "CONTAINS", "NCONTAINS"),
+				},
+			},
+			"search_value": schema.StringAttribute{
+				Description: "Value to compare the field against.",
+				Required:    true,
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Lists the cloud integration instances matching the " +
+			"given filter. All matching instances are returned, regardless " +
+			"of how many pages of results are required to retrieve them.",
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.SingleNestedAttribute{
+				Description: "Criteria used to filter the integration " +
+					"instances. If not configured, all instances are returned.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"and": schema.ListNestedAttribute{
+						Description:  "Criteria that must all be matched.",
+						Optional:     true,
+						NestedObject: criteriaAttributes,
+					},
+					"or": schema.ListNestedAttribute{
+						Description:  "Criteria of which at least one must be matched.",
+						Optional:     true,
+						NestedObject: criteriaAttributes,
+					},
+				},
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "The matching integration instances.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "A unique identifier of the integration.",
+							Computed:    true,
+						},
+						"account_name": schema.StringAttribute{
+							Description: "Name of the cloud account connected " +
+								"to the integration.",
+							Computed: true,
+						},
+						"cloud_provider": schema.StringAttribute{
+							Description: "The cloud service provider of the " +
+								"integration.",
+							Computed: true,
+						},
+						"creation_time": schema.Int64Attribute{
+							Description: "Time the integration was created, " +
+								"in milliseconds since the Unix epoch.",
+							Computed: true,
+						},
+						"instance_name": schema.StringAttribute{
+							Description: "Name of the integration instance.",
+							Computed:    true,
+						},
+						"outpost_id": schema.StringAttribute{
+							Description: "ID of the outpost used for scanning.",
+							Computed:    true,
+						},
+						"scan_mode": schema.StringAttribute{
+							Description: "Scan mode of the integration.",
+							Computed:    true,
+						},
+						"scope": schema.StringAttribute{
+							Description: "Scope of the integration.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the integration.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (r *CloudIntegrationInstancesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerModels.CortexCloudSDKClients)
+
+	if !ok {
+		util.AddUnexpectedResourceConfigureTypeError(&resp.Diagnostics, "*http.Client", req.ProviderData)
+		return
+	}
+
+	r.client = client.CloudOnboarding
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *CloudIntegrationInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer util.PanicHandler(&resp.Diagnostics)
+
+	// Populate data source configuration into model
+	var config models.CloudIntegrationInstancesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve all pages of matching integrations from API
+	instances := []cloudonboarding.IntegrationInstance{}
+	seenIds := map[string]struct{}{}
+	for from := 0; ; from += listInstancesPageSize {
+		request := config.ToListRequest(ctx, &resp.Diagnostics, from, from+listInstancesPageSize)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		response, err := r.client.ListInstances(ctx, request)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cloud Integrations Data Source Read Error",
+				err.Error(),
+			)
+			return
+		}
+
+		page, err := response.Marshal()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Value Conversion Error",
+				err.Error(),
+			)
+			return
+		}
+
+		added := 0
+		for _, instance := range page {
+			if _, ok := seenIds[instance.Id]; ok {
+				continue
+			}
+			seenIds[instance.Id] = struct{}{}
+			instances = append(instances, instance)
+			added++
+		}
+
+		// A short page is the last one. The total number of matching
+		// integrations is only used if the API reports it, as a count of
+		// zero may also mean that it was omitted. A page without any new
+		// integrations means the API ignored the requested range, so
+		// stop rather than requesting the same results forever.
+		if len(page) < listInstancesPageSize || added == 0 {
+			break
+		}
+		if filterCount := response.Reply.FilterCount; filterCount > 0 && len(instances) >= filterCount {
+			break
+		}
+	}
+
+	// Refresh state values
+	config.RefreshPropertyValues(ctx, &resp.Diagnostics, instances)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
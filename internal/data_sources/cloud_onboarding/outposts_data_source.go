@@ -0,0 +1,184 @@
+package cloudonboarding
+
+import (
+	"context"
+
+	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
+	"github.com/mdboynton/cortex-cloud-go/enums"
+
+	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/cloud_onboarding"
+	providerModels "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/provider"
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// listOutpostsPageSize is the number of outposts requested from the API per
+// page.
+const listOutpostsPageSize = 100
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &OutpostsDataSource{}
+)
+
+// NewOutpostsDataSource is a helper function to simplify the provider implementation.
+func NewOutpostsDataSource() datasource.DataSource {
+	return &OutpostsDataSource{}
+}
+
+// OutpostsDataSource is the data source implementation.
+type OutpostsDataSource struct {
+	client *cloudonboarding.Client
+}
+
+// Metadata returns the data source type name.
+func (r *OutpostsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_outposts"
+}
+
+// Schema defines the schema for the data source.
+func (r *OutpostsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the outposts available for scanning cloud " +
+			"environments, which can be referenced by the `outpost_id` " +
+			"argument of `cortexcloud_cloud_integration_template`.",
+		Attributes: map[string]schema.Attribute{
+			"cloud_provider": schema.StringAttribute{
+				Description: "If configured, only outposts for this cloud " +
+					"service provider are returned. Must be one of `AWS`, " +
+					"`AZURE` or `GCP`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						enums.AllCloudProviders()...,
+					),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "If configured, only outposts in this region " +
+					"are returned.",
+				Optional: true,
+			},
+			"outposts": schema.ListNestedAttribute{
+				Description: "The matching outposts.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "A unique identifier of the outpost.",
+							Computed:    true,
+						},
+						"cloud_provider": schema.StringAttribute{
+							Description: "The cloud service provider hosting " +
+								"the outpost.",
+							Computed: true,
+						},
+						"region": schema.StringAttribute{
+							Description: "The region in which the outpost " +
+								"is deployed.",
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Health status of the outpost.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Whether the outpost is managed by " +
+								"Cortex Cloud or deployed in your own cloud " +
+								"infrastructure.",
+							Computed: true,
+						},
+						"creation_time": schema.Int64Attribute{
+							Description: "Time the outpost was created, in " +
+								"milliseconds since the Unix epoch.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (r *OutpostsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerModels.CortexCloudSDKClients)
+
+	if !ok {
+		util.AddUnexpectedResourceConfigureTypeError(&resp.Diagnostics, "*http.Client", req.ProviderData)
+		return
+	}
+
+	r.client = client.CloudOnboarding
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *OutpostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer util.PanicHandler(&resp.Diagnostics)
+
+	// Populate data source configuration into model
+	var config models.OutpostsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve all pages of matching outposts from API
+	outposts := []cloudonboarding.Outpost{}
+	seenIds := map[string]struct{}{}
+	for from := 0; ; from += listOutpostsPageSize {
+		request := config.ToListRequest(ctx, &resp.Diagnostics, from, from+listOutpostsPageSize)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		response, err := r.client.ListOutposts(ctx, request)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Outposts Data Source Read Error",
+				err.Error(),
+			)
+			return
+		}
+
+		added := 0
+		for _, outpost := range response.Reply.Data {
+			if _, ok := seenIds[outpost.OutpostId]; ok {
+				continue
+			}
+			seenIds[outpost.OutpostId] = struct{}{}
+			outposts = append(outposts, outpost)
+			added++
+		}
+
+		// A short page is the last one. The total number of matching
+		// outposts is only used if the API reports it, as a count of zero
+		// may also mean that it was omitted. A page without any new
+		// outposts means the API ignored the requested range, so stop
+		// rather than requesting the same results forever.
+		if len(response.Reply.Data) < listOutpostsPageSize || added == 0 {
+			break
+		}
+		if filterCount := response.Reply.FilterCount; filterCount > 0 && len(outposts) >= filterCount {
+			break
+		}
+	}
+
+	// Refresh state values
+	config.RefreshPropertyValues(ctx, &resp.Diagnostics, outposts)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported application security rule frameworks.
+const (
+	AppSecRuleFrameworkNameEnumArm            = "ARM"
+	AppSecRuleFrameworkNameEnumBicep          = "BICEP"
+	AppSecRuleFrameworkNameEnumCloudFormation = "CLOUDFORMATION"
+	AppSecRuleFrameworkNameEnumKubernetes     = "KUBERNETES"
+	AppSecRuleFrameworkNameEnumTerraform      = "TERRAFORM"
+	AppSecRuleFrameworkNameEnumTerraformPlan  = "TERRAFORMPLAN"
+)
+
+// AllAppSecRuleFrameworkNames returns the names of all supported
+// application security rule frameworks.
+func AllAppSecRuleFrameworkNames() []string {
+	return []string{
+		AppSecRuleFrameworkNameEnumArm,
+		AppSecRuleFrameworkNameEnumBicep,
+		AppSecRuleFrameworkNameEnumCloudFormation,
+		AppSecRuleFrameworkNameEnumKubernetes,
+		AppSecRuleFrameworkNameEnumTerraform,
+		AppSecRuleFrameworkNameEnumTerraformPlan,
+	}
+}
+
+// appSecRuleImplicitFrameworks maps each framework to the frameworks that
+// the API implicitly adds to a rule along with it.
+var appSecRuleImplicitFrameworks = map[string][]string{
+	AppSecRuleFrameworkNameEnumTerraform: {AppSecRuleFrameworkNameEnumTerraformPlan},
+}
+
+// isImplicitFramework returns true if the API implicitly adds the named
+// framework to rules that contain one of frameworks.
+func isImplicitFramework(name string, frameworks []string) bool {
+	for _, framework := range frameworks {
+		if slices.Contains(appSecRuleImplicitFrameworks[framework], name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// anyResourceType is the resource type value that matches every resource,
+// regardless of the framework.
+const anyResourceType = "all"
+
+// appSecRuleResourceTypeRegexes contains the format of the resource types
+// that the conditions of each framework's definition may reference.
+var appSecRuleResourceTypeRegexes = map[string]*regexp.Regexp{
+	AppSecRuleFrameworkNameEnumArm:            regexp.MustCompile(`^[A-Za-z0-9.]+(/[A-Za-z0-9]+)+$`),
+	AppSecRuleFrameworkNameEnumBicep:          regexp.MustCompile(`^[A-Za-z0-9.]+(/[A-Za-z0-9]+)+$`),
+	AppSecRuleFrameworkNameEnumCloudFormation: regexp.MustCompile(`^[A-Za-z0-9]+::[A-Za-z0-9]+::[A-Za-z0-9]+$`),
+	AppSecRuleFrameworkNameEnumKubernetes:     regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`),
+	AppSecRuleFrameworkNameEnumTerraform:      regexp.MustCompile(`^[a-z0-9_]+$`),
+	AppSecRuleFrameworkNameEnumTerraformPlan:  regexp.MustCompile(`^[a-z0-9_]+$`),
+}
+
+// appSecRuleResourceTypeExamples contains an example resource type for each
+// framework, used in diagnostics.
+var appSecRuleResourceTypeExamples = map[string]string{
+	AppSecRuleFrameworkNameEnumArm:            "Microsoft.Storage/storageAccounts",
+	AppSecRuleFrameworkNameEnumBicep:          "Microsoft.Storage/storageAccounts",
+	AppSecRuleFrameworkNameEnumCloudFormation: "AWS::S3::Bucket",
+	AppSecRuleFrameworkNameEnumKubernetes:     "Deployment",
+	AppSecRuleFrameworkNameEnumTerraform:      "aws_s3_bucket",
+	AppSecRuleFrameworkNameEnumTerraformPlan:  "aws_s3_bucket",
+}
+
+// ValidateFrameworkDefinition checks that the YAML definition of the named
+// framework contains a definition section, and that the resource types
+// referenced by its conditions match the format used by the framework.
+// Errors are reported on p, which is the path of the definition attribute.
+func ValidateFrameworkDefinition(diagnostics *diag.Diagnostics, p path.Path, frameworkName string, definition string) {
+	var document map[string]any
+	if err := yaml.Unmarshal([]byte(definition), &document); err != nil {
+		diagnostics.AddAttributeError(
+			p,
+			"Invalid Framework Definition",
+			fmt.Sprintf("The %s framework definition is not a valid YAML mapping: %s", frameworkName, err.Error()),
+		)
+		return
+	}
+
+	logic, ok := document["definition"].(map[string]any)
+	if !ok {
+		diagnostics.AddAttributeError(
+			p,
+			"Invalid Framework Definition",
+			fmt.Sprintf("The %s framework definition must contain a \"definition\" mapping.", frameworkName),
+		)
+		return
+	}
+
+	resourceTypeRegex, ok := appSecRuleResourceTypeRegexes[frameworkName]
+	if !ok {
+		return
+	}
+
+	for _, resourceType := range definitionResourceTypes(logic) {
+		if resourceType == anyResourceType || resourceTypeRegex.MatchString(resourceType) {
+			continue
+		}
+
+		diagnostics.AddAttributeError(
+			p,
+			"Invalid Framework Definition",
+			fmt.Sprintf("Resource type %q is not a valid %s resource type, e.g. %q.", resourceType, frameworkName, appSecRuleResourceTypeExamples[frameworkName]),
+		)
+	}
+}
+
+// definitionResourceTypes returns the resource types referenced by a
+// definition condition and all of its nested "and" and "or" conditions.
+func definitionResourceTypes(condition map[string]any) []string {
+	var resourceTypes []string
+
+	switch value := condition["resource_types"].(type) {
+	case string:
+		resourceTypes = append(resourceTypes, value)
+	case []any:
+		for _, element := range value {
+			if resourceType, ok := element.(string); ok {
+				resourceTypes = append(resourceTypes, resourceType)
+			}
+		}
+	}
+
+	for _, key := range []string{"and", "or"} {
+		nestedConditions, _ := condition[key].([]any)
+		for _, nestedCondition := range nestedConditions {
+			if nested, ok := nestedCondition.(map[string]any); ok {
+				resourceTypes = append(resourceTypes, definitionResourceTypes(nested)...)
+			}
+		}
+	}
+
+	return resourceTypes
+}
@@ -5,14 +5,20 @@ package models
 
 import (
 	"context"
+	"fmt"
 	"slices"
 	"strings"
 
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/customtypes"
 	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/util"
 	"github.com/mdboynton/cortex-cloud-go/appsec"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gopkg.in/yaml.v3"
 )
 
 // *********************************************************
@@ -47,18 +53,19 @@ type ApplicationSecurityRuleModel struct {
 }
 
 type FrameworkModel struct {
-	Name types.String `tfsdk:"name"`
-	//Definition             FrameworkDefinitionModel
-	Definition             types.String `tfsdk:"definition"`
-	DefinitionLink         types.String `tfsdk:"definition_link"`
-	RemediationDescription types.String `tfsdk:"remediation_description"`
+	Name                   types.String                `tfsdk:"name"`
+	Definition             customtypes.YAMLStringValue `tfsdk:"definition"`
+	DefinitionBlock        types.Object                `tfsdk:"definition_block"`
+	DefinitionLink         types.String                `tfsdk:"definition_link"`
+	RemediationDescription types.String                `tfsdk:"remediation_description"`
 }
 
+// FrameworkDefinitionModel is the structured alternative to a YAML
+// framework definition.
 type FrameworkDefinitionModel struct {
-	Metadata *FrameworkDefinitionMetadataModel `tfsdk:"metadata" yaml:"metadata,omitempty"`
-	Scope    FrameworkDefinitionScopeModel     `tfsdk:"scope" yaml:"scope,omitempty"`
-	//Definition FrameworkDefinitionLogicModel     `tfsdk:"definition" yaml:"definition,omitempty"`
-	Definition types.Dynamic `tfsdk:"definition" yaml:"definition,omitempty"`
+	Provider types.String `tfsdk:"provider"`
+	And      types.List   `tfsdk:"and"`
+	Or       types.List   `tfsdk:"or"`
 }
 
 type FrameworkDefinitionMetadataModel struct {
@@ -68,291 +75,226 @@ type FrameworkDefinitionMetadataModel struct {
 	Severity   types.String `tfsdk:"severity" yaml:"severity"`
 }
 
-type FrameworkDefinitionScopeModel struct {
-	Provider types.String `tfsdk:"provider" yaml:"provider,omitempty"`
+// FrameworkDefinitionLogicConditionModel is a single condition of a
+// structured framework definition. Conditions at the maximum nesting depth
+// have no "and" or "or" attributes, so conditions are read from their
+// object values rather than with ObjectAs.
+type FrameworkDefinitionLogicConditionModel struct {
+	ConditionType types.String `tfsdk:"condition_type"`
+	ResourceTypes types.List   `tfsdk:"resource_types"`
+	Attribute     types.String `tfsdk:"attribute"`
+	Operator      types.String `tfsdk:"operator"`
+	Value         types.String `tfsdk:"value"`
+	And           types.List   `tfsdk:"and"`
+	Or            types.List   `tfsdk:"or"`
 }
 
-type FrameworkDefinitionLogicModel struct {
-	And []FrameworkDefinitionLogicConditionModel `tfsdk:"and" yaml:"and,omitempty"`
-	Or  []FrameworkDefinitionLogicConditionModel `tfsdk:"or" yaml:"or,omitempty"`
+// MaxDefinitionConditionDepth is the number of levels of conditions that
+// the "and" and "or" attributes of definition_block may contain.
+const MaxDefinitionConditionDepth = 3
+
+// DefinitionConditionAttrTypes returns the attribute types of a
+// definition_block condition that may contain depth-1 further levels of
+// nested conditions.
+func DefinitionConditionAttrTypes(depth int) map[string]attr.Type {
+	attrTypes := map[string]attr.Type{
+		"condition_type": types.StringType,
+		"resource_types": types.ListType{ElemType: types.StringType},
+		"attribute":      types.StringType,
+		"operator":       types.StringType,
+		"value":          types.StringType,
+	}
+
+	if depth > 1 {
+		nestedType := types.ListType{ElemType: types.ObjectType{AttrTypes: DefinitionConditionAttrTypes(depth - 1)}}
+		attrTypes["and"] = nestedType
+		attrTypes["or"] = nestedType
+	}
+
+	return attrTypes
 }
 
-type FrameworkDefinitionLogicConditionModel struct {
-	ConditionType types.String                             `tfsdk:"condition_type" yaml:"cond_type,omitempty"`
-	ResourceTypes types.List                               `tfsdk:"resource_types" yaml:"resource_types,omitempty"`
-	Attribute     types.String                             `tfsdk:"attribute" yaml:"attribute,omitempty"`
-	Operator      types.String                             `tfsdk:"operator" yaml:"operator,omitempty"`
-	Value         types.String                             `tfsdk:"value" yaml:"value,omitempty"`
-	And           []FrameworkDefinitionLogicConditionModel `tfsdk:"and" yaml:"and,omitempty"`
-	Or            []FrameworkDefinitionLogicConditionModel `tfsdk:"or" yaml:"or,omitempty"`
+// FrameworkDefinitionAttrTypes returns the attribute types of the
+// definition_block attribute.
+func FrameworkDefinitionAttrTypes() map[string]attr.Type {
+	conditionsType := types.ListType{ElemType: types.ObjectType{AttrTypes: DefinitionConditionAttrTypes(MaxDefinitionConditionDepth)}}
+
+	return map[string]attr.Type{
+		"provider": types.StringType,
+		"and":      conditionsType,
+		"or":       conditionsType,
+	}
+}
+
+// frameworkDefinitionDocument is the Checkov-style YAML document that the
+// API expects as a framework definition.
+type frameworkDefinitionDocument struct {
+	Scope      frameworkDefinitionScope     `yaml:"scope"`
+	Definition frameworkDefinitionCondition `yaml:"definition"`
+}
+
+type frameworkDefinitionScope struct {
+	Provider string `yaml:"provider"`
+}
+
+type frameworkDefinitionCondition struct {
+	ConditionType string                         `yaml:"cond_type,omitempty"`
+	ResourceTypes []string                       `yaml:"resource_types,omitempty"`
+	Attribute     string                         `yaml:"attribute,omitempty"`
+	Operator      string                         `yaml:"operator,omitempty"`
+	Value         *yaml.Node                     `yaml:"value,omitempty"`
+	And           []frameworkDefinitionCondition `yaml:"and,omitempty"`
+	Or            []frameworkDefinitionCondition `yaml:"or,omitempty"`
+}
+
+// DefinitionBlockToYAML serializes the definition_block attribute into the
+// YAML definition expected by the API. If any value of the block is not
+// known yet, an unknown value is returned. Errors are reported on the
+// attributes below p, which is the path of the definition_block attribute.
+func (m FrameworkModel) DefinitionBlockToYAML(ctx context.Context, diagnostics *diag.Diagnostics, p path.Path) customtypes.YAMLStringValue {
+	if m.DefinitionBlock.IsNull() {
+		return customtypes.NewYAMLStringNull()
+	}
+
+	terraformValue, err := m.DefinitionBlock.ToTerraformValue(ctx)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			p,
+			"Value Conversion Error",
+			err.Error(),
+		)
+		return customtypes.NewYAMLStringNull()
+	}
+	if !terraformValue.IsFullyKnown() {
+		return customtypes.NewYAMLStringUnknown()
+	}
+
+	var block FrameworkDefinitionModel
+	diagnostics.Append(m.DefinitionBlock.As(ctx, &block, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return customtypes.NewYAMLStringNull()
+	}
+
+	document := frameworkDefinitionDocument{
+		Scope: frameworkDefinitionScope{
+			Provider: block.Provider.ValueString(),
+		},
+		Definition: frameworkDefinitionCondition{
+			And: definitionConditionsFromList(ctx, diagnostics, p.AtName("and"), block.And),
+			Or:  definitionConditionsFromList(ctx, diagnostics, p.AtName("or"), block.Or),
+		},
+	}
+	if diagnostics.HasError() {
+		return customtypes.NewYAMLStringNull()
+	}
+
+	var buf strings.Builder
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		diagnostics.AddAttributeError(
+			p,
+			"Error Converting YAML",
+			err.Error(),
+		)
+		return customtypes.NewYAMLStringNull()
+	}
+
+	return customtypes.NewYAMLStringValue(buf.String())
+}
+
+// definitionConditionsFromList converts a list of definition conditions,
+// including any nested "and" and "or" conditions.
+func definitionConditionsFromList(ctx context.Context, diagnostics *diag.Diagnostics, p path.Path, list types.List) []frameworkDefinitionCondition {
+	if list.IsNull() {
+		return nil
+	}
+
+	var conditions []frameworkDefinitionCondition
+	for idx, element := range list.Elements() {
+		conditionPath := p.AtListIndex(idx)
+
+		conditionObject, ok := element.(types.Object)
+		if !ok {
+			diagnostics.AddAttributeError(
+				conditionPath,
+				"Invalid Type",
+				fmt.Sprintf("Expected type %T, recieved %T", types.Object{}, element),
+			)
+			return nil
+		}
+
+		attributes := conditionObject.Attributes()
+		stringAttribute := func(name string) string {
+			value, _ := attributes[name].(types.String)
+			return value.ValueString()
+		}
+		listAttribute := func(name string) types.List {
+			value, ok := attributes[name].(types.List)
+			if !ok {
+				return types.ListNull(types.StringType)
+			}
+			return value
+		}
+
+		condition := frameworkDefinitionCondition{
+			ConditionType: stringAttribute("condition_type"),
+			Attribute:     stringAttribute("attribute"),
+			Operator:      stringAttribute("operator"),
+			And:           definitionConditionsFromList(ctx, diagnostics, conditionPath.AtName("and"), listAttribute("and")),
+			Or:            definitionConditionsFromList(ctx, diagnostics, conditionPath.AtName("or"), listAttribute("or")),
+		}
+
+		if value := stringAttribute("value"); value != "" {
+			valueNode, err := definitionConditionValue(value)
+			if err != nil {
+				diagnostics.AddAttributeError(
+					conditionPath.AtName("value"),
+					"Invalid Definition Condition",
+					fmt.Sprintf("The condition value is not a valid YAML value: %s", err.Error()),
+				)
+			}
+			condition.Value = valueNode
+		}
+
+		if resourceTypes := listAttribute("resource_types"); !resourceTypes.IsNull() {
+			diagnostics.Append(resourceTypes.ElementsAs(ctx, &condition.ResourceTypes, false)...)
+		}
+
+		if condition.ConditionType == "" && len(condition.And) == 0 && len(condition.Or) == 0 {
+			diagnostics.AddAttributeError(
+				conditionPath,
+				"Invalid Definition Condition",
+				"Each condition must either set condition_type or contain nested \"and\" or \"or\" conditions.",
+			)
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions
 }
 
-//func (m FrameworkDefinitionModel) GetSchema() schema.SingleNestedAttribute {
-//	return schema.SingleNestedAttribute{
-//		Description: "TODO",
-//		Required:    true,
-//		Attributes: map[string]schema.Attribute{
-//			"metadata":   FrameworkDefinitionMetadataModel{}.GetSchema(),
-//			"scope":      FrameworkDefinitionScopeModel{}.GetSchema(),
-//			"definition": FrameworkDefinitionLogicModel{}.GetSchema(),
-//		},
-//	}
-//}
-//
-//func (m FrameworkDefinitionMetadataModel) GetSchema() schema.SingleNestedAttribute {
-//	return schema.SingleNestedAttribute{
-//		Description: "TODO",
-//		Optional:    true,
-//		Computed:    true,
-//		Attributes: map[string]schema.Attribute{
-//			"name": schema.StringAttribute{
-//				Description: "TODO",
-//				Optional:    true,
-//				Computed:    true,
-//			},
-//			"guidelines": schema.StringAttribute{
-//				Description: "TODO",
-//				Optional:    true,
-//				Computed:    true,
-//			},
-//			"category": schema.StringAttribute{
-//				Description: "TODO",
-//				Optional:    true,
-//				Computed:    true,
-//			},
-//			"severity": schema.StringAttribute{
-//				Description: "TODO",
-//				Optional:    true,
-//				Computed:    true,
-//			},
-//		},
-//	}
-//}
-//
-//func (m FrameworkDefinitionScopeModel) GetSchema() schema.SingleNestedAttribute {
-//	return schema.SingleNestedAttribute{
-//		Description: "TODO",
-//		Required:    true,
-//		Attributes: map[string]schema.Attribute{
-//			"provider": schema.StringAttribute{
-//				Description: "TODO",
-//				Required:    true,
-//			},
-//		},
-//	}
-//}
-//
-//// func (m FrameworkDefinitionLogicModel) GetSchema() schema.SingleNestedAttribute {
-//func (m FrameworkDefinitionLogicModel) GetSchema() schema.DynamicAttribute {
-//	return schema.DynamicAttribute{
-//		Description: "TODO",
-//		Required:    true,
-//		//Attributes: map[string]schema.Attribute{
-//		//	"and": FrameworkDefinitionLogicConditionModel{}.GetSchema(),
-//		//	"or":  FrameworkDefinitionLogicConditionModel{}.GetSchema(),
-//		//},
-//	}
-//}
-//
-//func (m FrameworkDefinitionLogicConditionModel) GetSchema() schema.ListNestedAttribute {
-//	return schema.ListNestedAttribute{
-//		Description: "TODO",
-//		Required:    true,
-//		NestedObject: schema.NestedAttributeObject{
-//			Attributes: map[string]schema.Attribute{
-//				"condition_type": schema.StringAttribute{
-//					Description: "TODO",
-//					Required:    true,
-//				},
-//				"resource_types": schema.ListAttribute{
-//					Description: "TODO",
-//					Required:    true,
-//					ElementType: types.StringType,
-//				},
-//				"attribute": schema.StringAttribute{
-//					Description: "TODO",
-//					Required:    true,
-//				},
-//				"operator": schema.StringAttribute{
-//					Description: "TODO",
-//					Required:    true,
-//				},
-//				"value": schema.StringAttribute{
-//					Description: "TODO",
-//					Required:    true,
-//				},
-//				"and": FrameworkDefinitionLogicConditionModel{}.GetSchema(),
-//				"or":  FrameworkDefinitionLogicConditionModel{}.GetSchema(),
-//			},
-//		},
-//	}
-//}
-
-// TODO: put tfsdk tags on API models and replace explicit object creation with ElementsAs
-//func (m FrameworkModel) ToTerraform(ctx context.Context, diagnostics *diag.Diagnostics, name, category, severity string) appsec.Framework {
-//	var metadata *api.FrameworkDefinitionMetadata
-//	if m.Definition.Metadata == nil {
-//		metadata = nil
-//	} else {
-//		metadata = &api.FrameworkDefinitionMetadata{
-//			Name:       m.Definition.Metadata.Name.ValueString(),
-//			Guidelines: m.Definition.Metadata.Guidelines.ValueStringPointer(),
-//			Category:   m.Definition.Metadata.Category.ValueString(),
-//			Severity:   m.Definition.Metadata.Severity.ValueString(),
-//		}
-//	}
-//
-//	//switch underlyingType := m.Definition.Definition.UnderlyingValue().(type) {
-//	//case types.Object:
-//	//	break
-//	//default:
-//	//	diagnostics.AddError(
-//	//		"Invalid Type",
-//	//		fmt.Sprintf("Expected type %T, recieved %T", conditionsType, underlyingType),
-//	//	)
-//	//	return api.Framework{}
-//	//}
-//
-//	andConditions, orConditions := []api.FrameworkDefinitionLogicCondition{}, []api.FrameworkDefinitionLogicCondition{}
-//	//for _, andCondition := range m.Definition.Definition.And {
-//	//	andConditions = append(andConditions, andCondition.ToTerraform(ctx, diagnostics))
-//	//}
-//
-//	//for _, orCondition := range m.Definition.Definition.And {
-//	//	orConditions = append(orConditions, orCondition.ToTerraform(ctx, diagnostics))
-//	//}
-//
-//	//var definitionValue []string
-//	//diagnostics.Append(m.Definition.Definition.Value.ElementsAs(ctx, &definitionValue, false)...)
-//	//if diagnostics.HasError() {
-//	//	return api.Framework{}
-//	//}
-//
-//	return api.Framework{
-//		Name: m.Name.ValueString(),
-//		Definition: api.FrameworkDefinition{
-//			Metadata: metadata,
-//			Scope: api.FrameworkDefinitionScope{
-//				Provider: m.Definition.Scope.Provider.ValueString(),
-//			},
-//			Definition: api.FrameworkDefinitionLogic{
-//				And: andConditions,
-//				//ConditionType: m.Definition.Definition.ConditionType.ValueString(),
-//				Or: orConditions,
-//				//Value:         definitionValue,
-//			},
-//		},
-//		DefinitionLink:         m.DefinitionLink.ValueString(),
-//		RemediationDescription: m.RemediationDescription.ValueStringPointer(),
-//	}
-//}
-
-//func (m FrameworkDefinitionLogicConditionModel) ToTerraform(ctx context.Context, diagnostics *diag.Diagnostics) api.FrameworkDefinitionLogicCondition {
-//	var (
-//		resourceTypes       []string
-//		nestedAndConditions []api.FrameworkDefinitionLogicCondition
-//		nestedOrConditions  []api.FrameworkDefinitionLogicCondition
-//	)
-//
-//	diagnostics.Append(m.ResourceTypes.ElementsAs(ctx, &resourceTypes, false)...)
-//
-//	for _, nestedAndCondition := range m.And {
-//		nestedAndConditions = append(nestedAndConditions, nestedAndCondition.ToTerraform(ctx, diagnostics))
-//	}
-//
-//	for _, nestedOrCondition := range m.Or {
-//		nestedOrConditions = append(nestedOrConditions, nestedOrCondition.ToTerraform(ctx, diagnostics))
-//	}
-//
-//	if diagnostics.HasError() {
-//		return api.FrameworkDefinitionLogicCondition{}
-//	}
-//
-//	return api.FrameworkDefinitionLogicCondition{
-//		ConditionType: m.ConditionType.ValueString(),
-//		ResourceTypes: resourceTypes,
-//		Attribute:     m.Attribute.ValueString(),
-//		Operator:      m.Operator.ValueString(),
-//		Value:         m.Value.ValueString(),
-//		And:           nestedAndConditions,
-//		Or:            nestedOrConditions,
-//	}
-//}
-//
-//func (m *FrameworkModel) FromAPI(ctx context.Context, diagnostics *diag.Diagnostics, framework appsecAPI.Framework) {
-//	var metadata *FrameworkDefinitionMetadataModel
-//	if m.Definition.Metadata == nil {
-//		metadata = nil
-//	} else {
-//		metadata = &FrameworkDefinitionMetadataModel{
-//			Name:       types.StringValue(framework.Definition.Metadata.Name),
-//			Guidelines: types.StringPointerValue(m.Definition.Metadata.Guidelines.ValueStringPointer()),
-//			Category:   types.StringValue(m.Definition.Metadata.Category.ValueString()),
-//			Severity:   types.StringValue(m.Definition.Metadata.Severity.ValueString()),
-//		}
-//	}
-//
-//	andConditions, orConditions := []FrameworkDefinitionLogicConditionModel{}, []FrameworkDefinitionLogicConditionModel{}
-//	for _, andCondition := range framework.Definition.Definition.And {
-//		nestedAndCondition := FrameworkDefinitionLogicConditionModel{}
-//		nestedAndCondition.FromAPI(ctx, diagnostics, andCondition)
-//		andConditions = append(andConditions, nestedAndCondition)
-//	}
-//
-//	for _, orCondition := range framework.Definition.Definition.And {
-//		nestedAndCondition := FrameworkDefinitionLogicConditionModel{}
-//		nestedAndCondition.FromAPI(ctx, diagnostics, orCondition)
-//		orConditions = append(orConditions, nestedAndCondition)
-//	}
-//
-//	m.Name = types.StringValue(framework.Name)
-//	m.Definition = FrameworkDefinitionModel{
-//		Metadata: metadata,
-//		Scope: FrameworkDefinitionScopeModel{
-//			Provider: types.StringValue(m.Definition.Scope.Provider.ValueString()),
-//		},
-//		//Definition: FrameworkDefinitionLogicModel{
-//		//	//And: andConditions,
-//		//	//Or:  orConditions,
-//		//},
-//	}
-//	m.DefinitionLink = types.StringValue(framework.DefinitionLink)
-//	m.RemediationDescription = types.StringPointerValue(framework.RemediationDescription)
-//}
-//
-//func (m *FrameworkDefinitionLogicConditionModel) FromAPI(ctx context.Context, diagnostics *diag.Diagnostics, frameworkDefinition api.FrameworkDefinitionLogicCondition) {
-//	var (
-//		nestedAndConditions []FrameworkDefinitionLogicConditionModel
-//		nestedOrConditions  []FrameworkDefinitionLogicConditionModel
-//	)
-//
-//	resourceTypes, diags := types.ListValueFrom(ctx, types.StringType, frameworkDefinition.ResourceTypes)
-//	diagnostics.Append(diags...)
-//
-//	for _, nestedAndCondition := range frameworkDefinition.And {
-//		nestedAndYamlConditionModel := FrameworkDefinitionLogicConditionModel{}
-//		nestedAndYamlConditionModel.FromAPI(ctx, diagnostics, nestedAndCondition)
-//		nestedAndConditions = append(nestedAndConditions, nestedAndYamlConditionModel)
-//	}
-//
-//	for _, nestedOrCondition := range frameworkDefinition.Or {
-//		nestedOrYamlConditionModel := FrameworkDefinitionLogicConditionModel{}
-//		nestedOrYamlConditionModel.FromAPI(ctx, diagnostics, nestedOrCondition)
-//		nestedOrConditions = append(nestedOrConditions, nestedOrYamlConditionModel)
-//	}
-//
-//	if diagnostics.HasError() {
-//		return
-//	}
-//
-//	m.ConditionType = types.StringValue(m.ConditionType.ValueString())
-//	m.ResourceTypes = resourceTypes
-//	m.Attribute = types.StringValue(m.Attribute.ValueString())
-//	m.Operator = types.StringValue(m.Operator.ValueString())
-//	m.Value = types.StringValue(m.Value.ValueString())
-//	m.And = nestedAndConditions
-//	m.Or = nestedOrConditions
-//}
+// definitionConditionValue parses the value of a definition condition as a
+// YAML value, so that its type is resolved the same way as in a YAML
+// definition: `true` is a boolean, `10` is a number and `"10"` is a string.
+func definitionConditionValue(value string) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &document); err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	}
+
+	valueNode := document.Content[0]
+	valueNode.HeadComment = ""
+	valueNode.LineComment = ""
+	valueNode.FootComment = ""
+
+	return valueNode, nil
+}
 
 // *********************************************************
 // Request conversion functions
@@ -363,14 +305,9 @@ func (m *ApplicationSecurityRuleModel) ToCreateOrCloneRequest(ctx context.Contex
 		return appsec.CreateOrCloneRequest{}
 	}
 
-	var frameworks []appsec.FrameworkData
-	for _, f := range m.Frameworks {
-		frameworks = append(frameworks, appsec.FrameworkData{
-			Name:                   f.Name.ValueString(),
-			Definition:             f.Definition.ValueString(),
-			RemediationDescription: f.RemediationDescription.ValueString(),
-			DefinitionLink:         f.DefinitionLink.ValueString(),
-		})
+	frameworks := m.toFrameworkData(diagnostics)
+	if diagnostics.HasError() {
+		return appsec.CreateOrCloneRequest{}
 	}
 
 	return appsec.CreateOrCloneRequest{
@@ -398,14 +335,9 @@ func (m *ApplicationSecurityRuleModel) ToUpdateRequest(ctx context.Context, diag
 		}
 	}
 
-	var frameworks []appsec.FrameworkData
-	for _, f := range m.Frameworks {
-		frameworks = append(frameworks, appsec.FrameworkData{
-			Name:                   f.Name.ValueString(),
-			Definition:             f.Definition.ValueString(),
-			RemediationDescription: f.RemediationDescription.ValueString(),
-			DefinitionLink:         f.DefinitionLink.ValueString(),
-		})
+	frameworks := m.toFrameworkData(diagnostics)
+	if diagnostics.HasError() {
+		return appsec.UpdateRequest{}
 	}
 
 	name := m.Name.ValueString()
@@ -430,17 +362,150 @@ func (m *ApplicationSecurityRuleModel) ToUpdateRequest(ctx context.Context, diag
 // *********************************************************
 // Helper functions
 // *********************************************************
+// toFrameworkData converts the frameworks into their API representation,
+// with the rule metadata injected into each definition.
+func (m *ApplicationSecurityRuleModel) toFrameworkData(diagnostics *diag.Diagnostics) []appsec.FrameworkData {
+	var frameworks []appsec.FrameworkData
+	for _, f := range m.Frameworks {
+		definition, err := InjectDefinitionMetadata(f.Definition.ValueString(), m.DefinitionMetadata(f.RemediationDescription))
+		if err != nil {
+			diagnostics.AddError(
+				"Error Converting YAML",
+				fmt.Sprintf("Failed to add metadata to the %s framework definition: %s", f.Name.ValueString(), err.Error()),
+			)
+			return nil
+		}
+
+		frameworks = append(frameworks, appsec.FrameworkData{
+			Name:                   f.Name.ValueString(),
+			Definition:             definition,
+			RemediationDescription: f.RemediationDescription.ValueString(),
+			DefinitionLink:         f.DefinitionLink.ValueString(),
+		})
+	}
+
+	return frameworks
+}
+
+// DefinitionMetadataEntry is a key of the "metadata" section of a framework
+// definition and its value.
+type DefinitionMetadataEntry struct {
+	Key   string
+	Value string
+}
+
+// DefinitionMetadata returns the metadata of the rule, in the order in
+// which it is injected into framework definitions. The guidelines are the
+// remediation description of the framework, falling back to the rule
+// description, and are omitted if both are empty.
+func (m *ApplicationSecurityRuleModel) DefinitionMetadata(remediationDescription types.String) []DefinitionMetadataEntry {
+	guidelines := remediationDescription.ValueString()
+	if guidelines == "" {
+		guidelines = m.Description.ValueString()
+	}
+
+	metadata := []DefinitionMetadataEntry{
+		{Key: "name", Value: m.Name.ValueString()},
+	}
+	if guidelines != "" {
+		metadata = append(metadata, DefinitionMetadataEntry{Key: "guidelines", Value: guidelines})
+	}
+	metadata = append(metadata,
+		DefinitionMetadataEntry{Key: "category", Value: strings.ToLower(m.Category.ValueString())},
+		DefinitionMetadataEntry{Key: "severity", Value: strings.ToLower(m.Severity.ValueString())},
+	)
+
+	return metadata
+}
+
+// InjectDefinitionMetadata returns the YAML definition with a top-level
+// "metadata" section as its first key. Keys already present in the
+// section keep their value and position, and the missing entries of
+// metadata are appended in order, so the result is deterministic.
+func InjectDefinitionMetadata(definition string, metadata []DefinitionMetadataEntry) (string, error) {
+	var rootNode yaml.Node
+	if err := yaml.Unmarshal([]byte(definition), &rootNode); err != nil {
+		return "", err
+	}
+
+	if rootNode.Kind != yaml.DocumentNode || len(rootNode.Content) == 0 || rootNode.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("the root of the YAML definition must be a mapping")
+	}
+
+	// Detach any existing metadata section so it can be placed first
+	mappingNode := rootNode.Content[0]
+	metadataNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	content := []*yaml.Node{}
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		if mappingNode.Content[i].Value == "metadata" && mappingNode.Content[i+1].Kind == yaml.MappingNode {
+			metadataNode = mappingNode.Content[i+1]
+			continue
+		}
+		content = append(content, mappingNode.Content[i], mappingNode.Content[i+1])
+	}
+
+	existingKeys := map[string]bool{}
+	for i := 0; i+1 < len(metadataNode.Content); i += 2 {
+		existingKeys[metadataNode.Content[i].Value] = true
+	}
+
+	for _, entry := range metadata {
+		if existingKeys[entry.Key] {
+			continue
+		}
+
+		metadataNode.Content = append(metadataNode.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: entry.Key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: entry.Value},
+		)
+	}
+
+	metadataKeyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "metadata"}
+	mappingNode.Content = append([]*yaml.Node{metadataKeyNode, metadataNode}, content...)
+
+	var buf strings.Builder
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&rootNode); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
 func (m *ApplicationSecurityRuleModel) RefreshPropertyValues(ctx context.Context, diagnostics *diag.Diagnostics, response appsec.Rule) {
 	// TODO: create member functions for conversion to schema
 
+	// Frameworks that the API implicitly adds along with a configured
+	// framework (e.g. TERRAFORMPLAN for TERRAFORM) are excluded from the
+	// updated Frameworks value unless they are configured explicitly,
+	// otherwise Terraform will error on recieving an unexpected new value.
+	// When importing, there are no existing framework values to compare
+	// against, so the frameworks returned by the API are used instead.
+	configuredFrameworks := []string{}
+	for _, framework := range m.Frameworks {
+		configuredFrameworks = append(configuredFrameworks, strings.ToUpper(framework.Name.ValueString()))
+	}
+
+	parentFrameworks := configuredFrameworks
+	if m.Frameworks == nil {
+		parentFrameworks = []string{}
+		for _, framework := range response.Frameworks {
+			parentFrameworks = append(parentFrameworks, strings.ToUpper(framework.Name))
+		}
+	}
+
+	priorDefinitions := map[string]string{}
+	priorDefinitionBlocks := map[string]types.Object{}
+	for _, framework := range m.Frameworks {
+		priorDefinitions[strings.ToUpper(framework.Name.ValueString())] = framework.Definition.ValueString()
+		priorDefinitionBlocks[strings.ToUpper(framework.Name.ValueString())] = framework.DefinitionBlock
+	}
+
 	var frameworkValues []FrameworkModel
 	for _, framework := range response.Frameworks {
-		// If the TERRAFORM framework exists in the resource configuration and
-		// the TERRAFORMPLAN framework does not exist in the current resource
-		// state, do not include the TERRAFORMPLAN framework in the updated
-		// Frameworks value, otherwise Terraform will error on recieving an
-		// unexpected new value
-		if framework.Name == "TERRAFORMPLAN" && slices.ContainsFunc(m.Frameworks, func(f FrameworkModel) bool { return strings.ToUpper(f.Name.ValueString()) == "TERRAFORM" }) {
+		frameworkName := strings.ToUpper(framework.Name)
+		if !slices.Contains(configuredFrameworks, frameworkName) && isImplicitFramework(frameworkName, parentFrameworks) {
 			continue
 		}
 
@@ -453,14 +518,25 @@ func (m *ApplicationSecurityRuleModel) RefreshPropertyValues(ctx context.Context
 
 		frameworkValues = append(frameworkValues, FrameworkModel{
 			Name:                   types.StringValue(framework.Name),
-			Definition:             types.StringValue(framework.Definition),
+			Definition:             customtypes.NewYAMLStringValue(refreshDefinition(priorDefinitions[strings.ToUpper(framework.Name)], framework.Definition)),
+			DefinitionBlock:        refreshDefinitionBlock(priorDefinitionBlocks[strings.ToUpper(framework.Name)]),
 			RemediationDescription: types.StringValue(remediationDescription),
 			DefinitionLink:         types.StringValue(framework.DefinitionLink),
 		})
 	}
 
+	// Keep the frameworks in their configured order, since the API does not
+	// necessarily return them in the order they were sent
+	slices.SortStableFunc(frameworkValues, func(a, b FrameworkModel) int {
+		return configuredFrameworkIndex(configuredFrameworks, a) - configuredFrameworkIndex(configuredFrameworks, b)
+	})
+
 	labels, diags := types.SetValueFrom(ctx, types.StringType, response.Labels)
+	diagnostics.Append(diags...)
+
 	mitreTactics, diags := types.SetValueFrom(ctx, types.StringType, response.MitreTactics)
+	diagnostics.Append(diags...)
+
 	mitreTechniques, diags := types.SetValueFrom(ctx, types.StringType, response.MitreTechniques)
 	diagnostics.Append(diags...)
 
@@ -494,3 +570,93 @@ func (m *ApplicationSecurityRuleModel) RefreshPropertyValues(ctx context.Context
 	m.SubCategory = types.StringValue(response.SubCategory)
 	m.UpdatedAt = types.StringValue(response.UpdatedAt.Value)
 }
+
+// configuredFrameworkIndex returns the index of the framework in the
+// configured framework names, or the number of configured frameworks if it
+// is not configured.
+func configuredFrameworkIndex(configuredFrameworks []string, framework FrameworkModel) int {
+	if idx := slices.Index(configuredFrameworks, strings.ToUpper(framework.Name.ValueString())); idx >= 0 {
+		return idx
+	}
+
+	return len(configuredFrameworks)
+}
+
+// refreshDefinitionBlock returns the definition_block value to store in
+// state. The API only returns YAML definitions, so the configured block is
+// kept as-is, and changes made outside of Terraform surface as a difference
+// in the generated definition attribute instead.
+func refreshDefinitionBlock(prior types.Object) types.Object {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.ObjectNull(FrameworkDefinitionAttrTypes())
+	}
+
+	return prior
+}
+
+// refreshDefinition returns the framework definition value to store in
+// state. The API injects a "metadata" section into each definition, which
+// the semantic equality of customtypes.YAMLStringValue ignores, so that the
+// prior definition is kept if it is otherwise equivalent. If there is no
+// prior definition (e.g. when importing), the injected section is removed
+// so that the value matches a configuration written without it.
+func refreshDefinition(prior, current string) string {
+	if prior == "" {
+		return stripDefinitionMetadata(current)
+	}
+
+	return current
+}
+
+// stripDefinitionMetadata removes the metadata keys injected by the API from
+// the YAML definition, and the "metadata" section itself if no other keys
+// remain, while preserving the order of the remaining keys. If the
+// definition cannot be parsed, it is returned unchanged.
+func stripDefinitionMetadata(definition string) string {
+	var rootNode yaml.Node
+	if err := yaml.Unmarshal([]byte(definition), &rootNode); err != nil {
+		return definition
+	}
+
+	if rootNode.Kind != yaml.DocumentNode || len(rootNode.Content) == 0 || rootNode.Content[0].Kind != yaml.MappingNode {
+		return definition
+	}
+
+	mappingNode := rootNode.Content[0]
+	content := []*yaml.Node{}
+	found := false
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		keyNode, valueNode := mappingNode.Content[i], mappingNode.Content[i+1]
+		if keyNode.Value != customtypes.YAMLMetadataKey || valueNode.Kind != yaml.MappingNode {
+			content = append(content, keyNode, valueNode)
+			continue
+		}
+
+		found = true
+		metadataContent := []*yaml.Node{}
+		for j := 0; j+1 < len(valueNode.Content); j += 2 {
+			if !slices.Contains(customtypes.YAMLIgnoredMetadataKeys, valueNode.Content[j].Value) {
+				metadataContent = append(metadataContent, valueNode.Content[j], valueNode.Content[j+1])
+			}
+		}
+
+		if len(metadataContent) > 0 {
+			valueNode.Content = metadataContent
+			content = append(content, keyNode, valueNode)
+		}
+	}
+
+	if !found {
+		return definition
+	}
+	mappingNode.Content = content
+
+	var buf strings.Builder
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&rootNode); err != nil {
+		return definition
+	}
+
+	return buf.String()
+}
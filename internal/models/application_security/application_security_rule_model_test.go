@@ -0,0 +1,393 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testCondition returns a definition_block condition object at the given
+// depth, with nested "and" and "or" conditions if depth allows them.
+func testCondition(depth int, attributes map[string]attr.Value) types.Object {
+	attrTypes := DefinitionConditionAttrTypes(depth)
+	values := map[string]attr.Value{
+		"condition_type": types.StringNull(),
+		"resource_types": types.ListNull(types.StringType),
+		"attribute":      types.StringNull(),
+		"operator":       types.StringNull(),
+		"value":          types.StringNull(),
+	}
+	if depth > 1 {
+		values["and"] = types.ListNull(attrTypes["and"].(types.ListType).ElemType)
+		values["or"] = types.ListNull(attrTypes["or"].(types.ListType).ElemType)
+	}
+
+	for name, value := range attributes {
+		values[name] = value
+	}
+
+	return types.ObjectValueMust(attrTypes, values)
+}
+
+func testAttributeCondition(depth int, attribute string, value types.String) types.Object {
+	return testCondition(depth, map[string]attr.Value{
+		"condition_type": types.StringValue("attribute"),
+		"resource_types": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("aws_s3_bucket")}),
+		"attribute":      types.StringValue(attribute),
+		"operator":       types.StringValue("equals"),
+		"value":          value,
+	})
+}
+
+func testConditions(depth int, conditions ...attr.Value) types.List {
+	return types.ListValueMust(types.ObjectType{AttrTypes: DefinitionConditionAttrTypes(depth)}, conditions)
+}
+
+func testDefinitionBlock(and, or types.List) types.Object {
+	conditionsType := types.ObjectType{AttrTypes: DefinitionConditionAttrTypes(MaxDefinitionConditionDepth)}
+	if and.IsNull() {
+		and = types.ListNull(conditionsType)
+	}
+	if or.IsNull() {
+		or = types.ListNull(conditionsType)
+	}
+
+	return types.ObjectValueMust(FrameworkDefinitionAttrTypes(), map[string]attr.Value{
+		"provider": types.StringValue("aws"),
+		"and":      and,
+		"or":       or,
+	})
+}
+
+func TestDefinitionBlockToYAML(t *testing.T) {
+	t.Parallel()
+
+	depth := MaxDefinitionConditionDepth
+
+	testCases := map[string]struct {
+		block           types.Object
+		expected        string
+		expectUnknown   bool
+		expectNull      bool
+		expectErrorPath path.Path
+	}{
+		"null": {
+			block:      types.ObjectNull(FrameworkDefinitionAttrTypes()),
+			expectNull: true,
+		},
+		"string-value": {
+			block: testDefinitionBlock(testConditions(depth, testAttributeCondition(depth, "acl", types.StringValue("private"))), types.List{}),
+			expected: `scope:
+  provider: aws
+definition:
+  and:
+    - cond_type: attribute
+      resource_types:
+        - aws_s3_bucket
+      attribute: acl
+      operator: equals
+      value: private
+`,
+		},
+		"typed-values": {
+			block: testDefinitionBlock(types.List{}, testConditions(depth,
+				testAttributeCondition(depth, "versioning.enabled", types.StringValue("true")),
+				testAttributeCondition(depth, "retention_days", types.StringValue("10")),
+				testAttributeCondition(depth, "name", types.StringValue(`"10"`)),
+			)),
+			expected: `scope:
+  provider: aws
+definition:
+  or:
+    - cond_type: attribute
+      resource_types:
+        - aws_s3_bucket
+      attribute: versioning.enabled
+      operator: equals
+      value: true
+    - cond_type: attribute
+      resource_types:
+        - aws_s3_bucket
+      attribute: retention_days
+      operator: equals
+      value: 10
+    - cond_type: attribute
+      resource_types:
+        - aws_s3_bucket
+      attribute: name
+      operator: equals
+      value: "10"
+`,
+		},
+		"nested-conditions": {
+			block: testDefinitionBlock(testConditions(depth,
+				testCondition(depth, map[string]attr.Value{
+					"or": testConditions(depth-1,
+						testAttributeCondition(depth-1, "acl", types.StringValue("private")),
+						testCondition(depth-1, map[string]attr.Value{
+							"condition_type": types.StringValue("attribute"),
+							"resource_types": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("aws_s3_bucket")}),
+							"attribute":      types.StringValue("policy"),
+							"operator":       types.StringValue("exists"),
+						}),
+					),
+				}),
+			), types.List{}),
+			expected: `scope:
+  provider: aws
+definition:
+  and:
+    - or:
+        - cond_type: attribute
+          resource_types:
+            - aws_s3_bucket
+          attribute: acl
+          operator: equals
+          value: private
+        - cond_type: attribute
+          resource_types:
+            - aws_s3_bucket
+          attribute: policy
+          operator: exists
+`,
+		},
+		"unknown-value": {
+			block:         testDefinitionBlock(testConditions(depth, testAttributeCondition(depth, "acl", types.StringUnknown())), types.List{}),
+			expectUnknown: true,
+		},
+		"missing-condition-type": {
+			block:           testDefinitionBlock(testConditions(depth, testCondition(depth, nil)), types.List{}),
+			expectErrorPath: path.Root("definition_block").AtName("and").AtListIndex(0),
+		},
+		"invalid-value": {
+			block:           testDefinitionBlock(testConditions(depth, testAttributeCondition(depth, "acl", types.StringValue("[private"))), types.List{}),
+			expectErrorPath: path.Root("definition_block").AtName("and").AtListIndex(0).AtName("value"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diagnostics diag.Diagnostics
+			framework := FrameworkModel{DefinitionBlock: testCase.block}
+			definition := framework.DefinitionBlockToYAML(context.Background(), &diagnostics, path.Root("definition_block"))
+
+			if len(testCase.expectErrorPath.Steps()) > 0 {
+				if !diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+
+				errorPath := diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path()
+				if !errorPath.Equal(testCase.expectErrorPath) {
+					t.Errorf("expected error on %s, got %s", testCase.expectErrorPath, errorPath)
+				}
+				return
+			}
+
+			if diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", diagnostics)
+			}
+
+			if definition.IsUnknown() != testCase.expectUnknown {
+				t.Errorf("expected unknown: %t, got %s", testCase.expectUnknown, definition)
+			}
+
+			if definition.IsNull() != testCase.expectNull {
+				t.Errorf("expected null: %t, got %s", testCase.expectNull, definition)
+			}
+
+			if definition.ValueString() != testCase.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", testCase.expected, definition.ValueString())
+			}
+		})
+	}
+}
+
+func TestDefinitionMetadata(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		description            types.String
+		remediationDescription types.String
+		expected               []DefinitionMetadataEntry
+	}{
+		"remediation-description": {
+			description:            types.StringValue("Rule description"),
+			remediationDescription: types.StringValue("Enable encryption"),
+			expected: []DefinitionMetadataEntry{
+				{Key: "name", Value: "rule"},
+				{Key: "guidelines", Value: "Enable encryption"},
+				{Key: "category", Value: "compute"},
+				{Key: "severity", Value: "high"},
+			},
+		},
+		"description-fallback": {
+			description:            types.StringValue("Rule description"),
+			remediationDescription: types.StringValue(""),
+			expected: []DefinitionMetadataEntry{
+				{Key: "name", Value: "rule"},
+				{Key: "guidelines", Value: "Rule description"},
+				{Key: "category", Value: "compute"},
+				{Key: "severity", Value: "high"},
+			},
+		},
+		"no-guidelines": {
+			description:            types.StringNull(),
+			remediationDescription: types.StringNull(),
+			expected: []DefinitionMetadataEntry{
+				{Key: "name", Value: "rule"},
+				{Key: "category", Value: "compute"},
+				{Key: "severity", Value: "high"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := ApplicationSecurityRuleModel{
+				Name:        types.StringValue("rule"),
+				Description: testCase.description,
+				Category:    types.StringValue("COMPUTE"),
+				Severity:    types.StringValue("HIGH"),
+			}
+
+			if metadata := rule.DefinitionMetadata(testCase.remediationDescription); !slices.Equal(metadata, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, metadata)
+			}
+		})
+	}
+}
+
+func TestInjectDefinitionMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata := []DefinitionMetadataEntry{
+		{Key: "name", Value: "rule"},
+		{Key: "guidelines", Value: "Enable encryption"},
+		{Key: "category", Value: "compute"},
+		{Key: "severity", Value: "high"},
+	}
+
+	testCases := map[string]struct {
+		definition  string
+		expected    string
+		expectError bool
+	}{
+		"no-metadata": {
+			definition: "scope:\n  provider: aws\ndefinition:\n  cond_type: attribute\n",
+			expected: `metadata:
+  name: rule
+  guidelines: Enable encryption
+  category: compute
+  severity: high
+scope:
+  provider: aws
+definition:
+  cond_type: attribute
+`,
+		},
+		"metadata-moved-first": {
+			definition: "scope:\n  provider: aws\nmetadata:\n  name: rule\n",
+			expected: `metadata:
+  name: rule
+  guidelines: Enable encryption
+  category: compute
+  severity: high
+scope:
+  provider: aws
+`,
+		},
+		"preserves-user-keys": {
+			definition: "metadata:\n  id: CUSTOM_1\n  severity: critical\nscope:\n  provider: aws\n",
+			expected: `metadata:
+  id: CUSTOM_1
+  severity: critical
+  name: rule
+  guidelines: Enable encryption
+  category: compute
+scope:
+  provider: aws
+`,
+		},
+		"not-a-mapping": {
+			definition:  "- scope\n",
+			expectError: true,
+		},
+		"empty": {
+			definition:  "",
+			expectError: true,
+		},
+		"invalid-yaml": {
+			definition:  "scope: [aws",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			definition, err := InjectDefinitionMetadata(testCase.definition, metadata)
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if definition != testCase.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", testCase.expected, definition)
+			}
+		})
+	}
+}
+
+func TestStripDefinitionMetadata(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		definition string
+		expected   string
+	}{
+		"injected-metadata": {
+			definition: "metadata:\n  name: rule\n  category: compute\n  severity: high\nscope:\n  provider: aws\n",
+			expected:   "scope:\n  provider: aws\n",
+		},
+		"user-metadata-preserved": {
+			definition: "metadata:\n  name: rule\n  id: CUSTOM_1\n  severity: high\nscope:\n  provider: aws\n",
+			expected:   "metadata:\n  id: CUSTOM_1\nscope:\n  provider: aws\n",
+		},
+		"no-metadata": {
+			definition: "scope: {provider: aws}\n",
+			expected:   "scope: {provider: aws}\n",
+		},
+		"invalid-yaml": {
+			definition: "scope: [aws",
+			expected:   "scope: [aws",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if definition := stripDefinitionMetadata(testCase.definition); definition != testCase.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", testCase.expected, definition)
+			}
+		})
+	}
+}
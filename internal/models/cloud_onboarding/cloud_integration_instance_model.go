@@ -18,6 +18,7 @@ import (
 // *********************************************************
 type CloudIntegrationInstanceModel struct {
 	Id                      types.String `tfsdk:"id"`
+	AccountId               types.String `tfsdk:"account_id"`
 	AdditionalCapabilities  types.Object `tfsdk:"additional_capabilities"`
 	CloudProvider           types.String `tfsdk:"cloud_provider"`
 	Collector               types.String `tfsdk:"collector"`
@@ -37,6 +38,16 @@ func (m *CloudIntegrationInstanceModel) ToGetRequest(ctx context.Context, diagno
 	}
 }
 
+// ToListRequest returns the request used to find the integration instance
+// when it is looked up by instance_name or account_id rather than by id.
+func (m *CloudIntegrationInstanceModel) ToListRequest(ctx context.Context, diagnostics *diag.Diagnostics) cloudonboarding.ListIntegrationInstancesRequest {
+	if !m.AccountId.IsNull() {
+		return ToLookupRequest(IntegrationSearchFieldAccountId, m.AccountId.ValueString())
+	}
+
+	return ToLookupRequest(IntegrationSearchFieldInstanceName, m.InstanceName.ValueString())
+}
+
 func (m *CloudIntegrationInstanceModel) RefreshPropertyValues(ctx context.Context, diagnostics *diag.Diagnostics, response cloudonboarding.GetIntegrationInstanceResponse) {
 	data, err := response.Marshal()
 	if err != nil {
@@ -0,0 +1,139 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package models
+
+import (
+	"context"
+
+	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// *********************************************************
+// Structs
+// *********************************************************
+type CloudIntegrationInstancesModel struct {
+	Filter    types.Object `tfsdk:"filter"`
+	Instances types.List   `tfsdk:"instances"`
+}
+
+type CriteriaFilterModel struct {
+	And []CriteriaModel `tfsdk:"and"`
+	Or  []CriteriaModel `tfsdk:"or"`
+}
+
+type CriteriaModel struct {
+	SearchField types.String `tfsdk:"search_field"`
+	SearchType  types.String `tfsdk:"search_type"`
+	SearchValue types.String `tfsdk:"search_value"`
+}
+
+type CloudIntegrationInstanceSummaryModel struct {
+	Id            types.String `tfsdk:"id"`
+	AccountName   types.String `tfsdk:"account_name"`
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	CreationTime  types.Int64  `tfsdk:"creation_time"`
+	InstanceName  types.String `tfsdk:"instance_name"`
+	OutpostId     types.String `tfsdk:"outpost_id"`
+	ScanMode      types.String `tfsdk:"scan_mode"`
+	Scope         types.String `tfsdk:"scope"`
+	Status        types.String `tfsdk:"status"`
+}
+
+// CloudIntegrationInstanceSummaryObjectType is the object type of the
+// elements of the instances attribute.
+var CloudIntegrationInstanceSummaryObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":             types.StringType,
+		"account_name":   types.StringType,
+		"cloud_provider": types.StringType,
+		"creation_time":  types.Int64Type,
+		"instance_name":  types.StringType,
+		"outpost_id":     types.StringType,
+		"scan_mode":      types.StringType,
+		"scope":          types.StringType,
+		"status":         types.StringType,
+	},
+}
+
+// ToListRequest returns the request for the page of integration instances
+// matching the configured filter between the indexes from and to.
+func (m *CloudIntegrationInstancesModel) ToListRequest(ctx context.Context, diagnostics *diag.Diagnostics, from, to int) cloudonboarding.ListIntegrationInstancesRequest {
+	var filter CriteriaFilterModel
+	if !m.Filter.IsNull() && !m.Filter.IsUnknown() {
+		diagnostics.Append(m.Filter.As(ctx, &filter, basetypes.ObjectAsOptions{})...)
+		if diagnostics.HasError() {
+			return cloudonboarding.ListIntegrationInstancesRequest{}
+		}
+	}
+
+	return cloudonboarding.ListIntegrationInstancesRequest{
+		RequestData: cloudonboarding.ListIntegrationInstancesRequestData{
+			FilterData: cloudonboarding.FilterData{
+				Filter: cloudonboarding.CriteriaFilter{
+					And: toCriteria(filter.And),
+					Or:  toCriteria(filter.Or),
+				},
+				Paging: cloudonboarding.PagingFilter{
+					From: from,
+					To:   to,
+				},
+			},
+		},
+	}
+}
+
+func toCriteria(criteria []CriteriaModel) []cloudonboarding.Criteria {
+	result := []cloudonboarding.Criteria{}
+	for _, c := range criteria {
+		result = append(result, cloudonboarding.Criteria{
+			SearchField: c.SearchField.ValueString(),
+			SearchType:  c.SearchType.ValueString(),
+			SearchValue: c.SearchValue.ValueString(),
+		})
+	}
+
+	return result
+}
+
+func (m *CloudIntegrationInstancesModel) RefreshPropertyValues(ctx context.Context, diagnostics *diag.Diagnostics, instances []cloudonboarding.IntegrationInstance) {
+	summaries := make([]CloudIntegrationInstanceSummaryModel, 0, len(instances))
+	for _, instance := range instances {
+		summary := CloudIntegrationInstanceSummaryModel{
+			Id:            types.StringValue(instance.Id),
+			AccountName:   types.StringNull(),
+			CloudProvider: types.StringValue(instance.CloudProvider),
+			CreationTime:  types.Int64Null(),
+			InstanceName:  types.StringValue(instance.InstanceName),
+			OutpostId:     types.StringNull(),
+			ScanMode:      types.StringValue(instance.Scan.ScanMethod),
+			Scope:         types.StringValue(instance.Scope),
+			Status:        types.StringValue(instance.Status),
+		}
+
+		if instance.AccountName != "" {
+			summary.AccountName = types.StringValue(instance.AccountName)
+		}
+		if instance.CreationTime != 0 {
+			summary.CreationTime = types.Int64Value(instance.CreationTime)
+		}
+		if instance.OutpostId != "" {
+			summary.OutpostId = types.StringValue(instance.OutpostId)
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	instancesValue, diags := types.ListValueFrom(ctx, CloudIntegrationInstanceSummaryObjectType, summaries)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	m.Instances = instancesValue
+}
@@ -5,9 +5,18 @@ package models
 
 import (
 	"context"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
+	"github.com/mdboynton/cortex-cloud-go/enums"
 
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
@@ -19,21 +28,34 @@ import (
 // Structs
 // *********************************************************
 type CloudIntegrationTemplateModel struct {
-	AccountDetails            types.Object `tfsdk:"account_details"`
-	AdditionalCapabilities    types.Object `tfsdk:"additional_capabilities"`
-	CloudProvider             types.String `tfsdk:"cloud_provider"`
-	CollectionConfiguration   types.Object `tfsdk:"collection_configuration"`
-	CustomResourcesTags       types.Set    `tfsdk:"custom_resources_tags"`
-	InstanceName              types.String `tfsdk:"instance_name"`
-	ScanMode                  types.String `tfsdk:"scan_mode"`
-	Scope                     types.String `tfsdk:"scope"`
-	ScopeModifications        types.Object `tfsdk:"scope_modifications"`
-	Status                    types.String `tfsdk:"status"`
-	TrackingGuid              types.String `tfsdk:"tracking_guid"`
-	OutpostId                 types.String `tfsdk:"outpost_id"`
-	AutomatedDeploymentLink   types.String `tfsdk:"automated_deployment_link"`
-	ManualDeploymentLink      types.String `tfsdk:"manual_deployment_link"`
-	CloudFormationTemplateUrl types.String `tfsdk:"cloud_formation_template_url"`
+	AccountDetails                types.Object   `tfsdk:"account_details"`
+	AdditionalCapabilities        types.Object   `tfsdk:"additional_capabilities"`
+	CloudProvider                 types.String   `tfsdk:"cloud_provider"`
+	CollectionConfiguration       types.Object   `tfsdk:"collection_configuration"`
+	CustomResourcesTags           types.Set      `tfsdk:"custom_resources_tags"`
+	TagsAll                       types.Set      `tfsdk:"tags_all"`
+	InstanceName                  types.String   `tfsdk:"instance_name"`
+	ScanMode                      types.String   `tfsdk:"scan_mode"`
+	Scope                         types.String   `tfsdk:"scope"`
+	ScopeModifications            types.Object   `tfsdk:"scope_modifications"`
+	Status                        types.String   `tfsdk:"status"`
+	AccountName                   types.String   `tfsdk:"account_name"`
+	CreationTime                  types.Int64    `tfsdk:"creation_time"`
+	WaitForStatus                 types.Object   `tfsdk:"wait_for_status"`
+	TrackingGuid                  types.String   `tfsdk:"tracking_guid"`
+	OutpostId                     types.String   `tfsdk:"outpost_id"`
+	AutomatedDeploymentLink       types.String   `tfsdk:"automated_deployment_link"`
+	ManualDeploymentLink          types.String   `tfsdk:"manual_deployment_link"`
+	CloudFormationTemplateUrl     types.String   `tfsdk:"cloud_formation_template_url"`
+	CloudFormationStackName       types.String   `tfsdk:"cloud_formation_stack_name"`
+	CloudFormationStackParameters types.Map      `tfsdk:"cloud_formation_stack_parameters"`
+	ArmTemplate                   types.String   `tfsdk:"arm_template"`
+	TerraformModule               types.String   `tfsdk:"terraform_module"`
+	Timeouts                      timeouts.Value `tfsdk:"timeouts"`
+}
+
+type WaitForStatusModel struct {
+	TargetStatus types.String `tfsdk:"target_status"`
 }
 
 func (m *CloudIntegrationTemplateModel) ToCreateRequest(ctx context.Context, diagnostics *diag.Diagnostics) cloudonboarding.CreateIntegrationTemplateRequest {
@@ -43,11 +65,11 @@ func (m *CloudIntegrationTemplateModel) ToCreateRequest(ctx context.Context, dia
 	var collectionConfiguration cloudonboarding.CollectionConfiguration
 	diagnostics.Append(m.CollectionConfiguration.As(ctx, &collectionConfiguration, basetypes.ObjectAsOptions{})...)
 
-	var customResourcesTags []cloudonboarding.Tag
-	diagnostics.Append(m.CustomResourcesTags.ElementsAs(ctx, &customResourcesTags, false)...)
+	customResourcesTags := m.requestTags(ctx, diagnostics)
 
-	var scopeModifications cloudonboarding.ScopeModifications
-	diagnostics.Append(m.ScopeModifications.As(ctx, &scopeModifications, basetypes.ObjectAsOptions{})...)
+	scopeModifications := m.scopeModificationsRequest(ctx, diagnostics)
+
+	accountDetails := m.accountDetailsRequest(ctx, diagnostics)
 
 	if diagnostics.HasError() {
 		return cloudonboarding.CreateIntegrationTemplateRequest{}
@@ -55,12 +77,14 @@ func (m *CloudIntegrationTemplateModel) ToCreateRequest(ctx context.Context, dia
 
 	request := cloudonboarding.CreateIntegrationTemplateRequest{
 		Data: cloudonboarding.CreateIntegrationTemplateRequestData{
+			AccountDetails:          accountDetails,
 			AdditionalCapabilities:  additionalCapabilities,
 			CloudProvider:           m.CloudProvider.ValueString(),
 			CollectionConfiguration: collectionConfiguration,
 			CustomResourcesTags:     customResourcesTags,
 			InstanceName:            m.InstanceName.ValueString(),
 			ScanMode:                m.ScanMode.ValueString(),
+			ScanEnvId:               m.OutpostId.ValueString(),
 			Scope:                   m.Scope.ValueString(),
 			ScopeModifications:      scopeModifications,
 		},
@@ -76,13 +100,25 @@ func (m *CloudIntegrationTemplateModel) ToGetRequest(ctx context.Context, diagno
 			SearchType:  "EQ",
 			SearchValue: m.TrackingGuid.ValueString(),
 		},
+	}
+
+	return newListIntegrationInstancesRequest(andFilters)
+}
+
+// ToLookupRequest returns a request matching the integration instances
+// whose searchField, e.g. "ID" (the tracking GUID) or "INSTANCE_NAME",
+// equals searchValue. Integrations are matched regardless of status.
+func ToLookupRequest(searchField string, searchValue string) cloudonboarding.ListIntegrationInstancesRequest {
+	return newListIntegrationInstancesRequest([]cloudonboarding.Criteria{
 		{
-			SearchField: "STATUS",
+			SearchField: searchField,
 			SearchType:  "EQ",
-			SearchValue: "PENDING",
+			SearchValue: searchValue,
 		},
-	}
+	})
+}
 
+func newListIntegrationInstancesRequest(andFilters []cloudonboarding.Criteria) cloudonboarding.ListIntegrationInstancesRequest {
 	return cloudonboarding.ListIntegrationInstancesRequest{
 		RequestData: cloudonboarding.ListIntegrationInstancesRequestData{
 			FilterData: cloudonboarding.FilterData{
@@ -105,11 +141,11 @@ func (m *CloudIntegrationTemplateModel) ToUpdateRequest(ctx context.Context, dia
 	var collectionConfiguration cloudonboarding.CollectionConfiguration
 	diagnostics.Append(m.CollectionConfiguration.As(ctx, &collectionConfiguration, basetypes.ObjectAsOptions{})...)
 
-	var customResourcesTags []cloudonboarding.Tag
-	diagnostics.Append(m.CustomResourcesTags.ElementsAs(ctx, &customResourcesTags, false)...)
+	customResourcesTags := m.requestTags(ctx, diagnostics)
 
-	var scopeModifications cloudonboarding.ScopeModifications
-	diagnostics.Append(m.ScopeModifications.As(ctx, &scopeModifications, basetypes.ObjectAsOptions{})...)
+	scopeModifications := m.scopeModificationsRequest(ctx, diagnostics)
+
+	accountDetails := m.accountDetailsRequest(ctx, diagnostics)
 
 	if diagnostics.HasError() {
 		return cloudonboarding.EditIntegrationInstanceRequest{}
@@ -117,6 +153,7 @@ func (m *CloudIntegrationTemplateModel) ToUpdateRequest(ctx context.Context, dia
 
 	return cloudonboarding.EditIntegrationInstanceRequest{
 		RequestData: cloudonboarding.EditIntegrationInstanceRequestData{
+			AccountDetails:          accountDetails,
 			AdditionalCapabilities:  additionalCapabilities,
 			CloudProvider:           m.CloudProvider.ValueString(),
 			CollectionConfiguration: collectionConfiguration,
@@ -137,54 +174,287 @@ func (m *CloudIntegrationTemplateModel) ToDeleteRequest(ctx context.Context, dia
 	}
 }
 
+// ApplyDefaultTags sets TagsAll to the result of merging the provider
+// default tags with the configured custom resource tags. Custom resource
+// tags take precedence over default tags with the same key.
+func (m *CloudIntegrationTemplateModel) ApplyDefaultTags(ctx context.Context, diagnostics *diag.Diagnostics, defaultTags map[string]string) {
+	if m.CustomResourcesTags.IsUnknown() {
+		m.TagsAll = types.SetUnknown(TagObjectType)
+		return
+	}
+
+	var customTags []TagModel
+	if !m.CustomResourcesTags.IsNull() {
+		diagnostics.Append(m.CustomResourcesTags.ElementsAs(ctx, &customTags, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	merged := map[string]string{}
+	for key, value := range defaultTags {
+		merged[key] = value
+	}
+
+	for _, tag := range customTags {
+		if tag.Key.IsUnknown() || tag.Value.IsUnknown() {
+			m.TagsAll = types.SetUnknown(TagObjectType)
+			return
+		}
+
+		merged[tag.Key.ValueString()] = tag.Value.ValueString()
+	}
+
+	keys := slices.Sorted(maps.Keys(merged))
+	tagsAll := make([]TagModel, 0, len(keys))
+	for _, key := range keys {
+		tagsAll = append(tagsAll, TagModel{
+			Key:   types.StringValue(key),
+			Value: types.StringValue(merged[key]),
+		})
+	}
+
+	tagsAllValue, diags := types.SetValueFrom(ctx, TagObjectType, tagsAll)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	m.TagsAll = tagsAllValue
+}
+
+// requestTags returns the tags to send to the API, which is the value of
+// TagsAll if it has been populated and CustomResourcesTags otherwise.
+func (m *CloudIntegrationTemplateModel) requestTags(ctx context.Context, diagnostics *diag.Diagnostics) []cloudonboarding.Tag {
+	tagsValue := m.TagsAll
+	if tagsValue.IsNull() || tagsValue.IsUnknown() {
+		tagsValue = m.CustomResourcesTags
+	}
+
+	var tags []cloudonboarding.Tag
+	if tagsValue.IsNull() || tagsValue.IsUnknown() {
+		return tags
+	}
+
+	diagnostics.Append(tagsValue.ElementsAs(ctx, &tags, false)...)
+
+	return tags
+}
+
+// refreshTags populates TagsAll with the tags returned by the API and
+// CustomResourcesTags with the subset of those tags that did not originate
+// from the provider default tags or the Cortex-managed tag, unless they are
+// also present in the current custom_resources_tags value.
+func (m *CloudIntegrationTemplateModel) refreshTags(ctx context.Context, diagnostics *diag.Diagnostics, tags []cloudonboarding.Tag, defaultTags map[string]string) {
+	configuredKeys := map[string]bool{}
+	if !m.CustomResourcesTags.IsNull() && !m.CustomResourcesTags.IsUnknown() {
+		var currentTags []TagModel
+		diagnostics.Append(m.CustomResourcesTags.ElementsAs(ctx, &currentTags, false)...)
+		if diagnostics.HasError() {
+			return
+		}
+
+		for _, tag := range currentTags {
+			configuredKeys[tag.Key.ValueString()] = true
+		}
+	}
+
+	customTags := []cloudonboarding.Tag{}
+	allTags := []cloudonboarding.Tag{}
+	for _, tag := range tags {
+		_, isDefaultTag := defaultTags[tag.Key]
+		isManagedByTag := tag.Key == ManagedByTagKey
+
+		if configuredKeys[tag.Key] || (!isDefaultTag && !isManagedByTag) {
+			customTags = append(customTags, tag)
+		}
+
+		if configuredKeys[tag.Key] || !isManagedByTag {
+			allTags = append(allTags, tag)
+		}
+	}
+
+	customTagsValue, diags := types.SetValueFrom(ctx, TagObjectType, customTags)
+	diagnostics.Append(diags...)
+
+	allTagsValue, diags := types.SetValueFrom(ctx, TagObjectType, allTags)
+	diagnostics.Append(diags...)
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	m.CustomResourcesTags = customTagsValue
+	m.TagsAll = allTagsValue
+}
+
+// RefreshComputedPropertyValues populates the values returned when the
+// template is generated. Values that were planned from the prior state are
+// kept as-is, since the template inputs they depend on did not change.
 func (m *CloudIntegrationTemplateModel) RefreshComputedPropertyValues(diagnostics *diag.Diagnostics, response cloudonboarding.CreateTemplateOrEditIntegrationInstanceResponse) {
 	data := response.Reply
 
-	var (
-		cloudFormationTemplateUrl string = ""
-		err                       error
-	)
+	if m.CloudFormationTemplateUrl.IsUnknown() {
+		cloudFormationTemplateUrl := ""
+		if m.CloudProvider.ValueString() == "AWS" {
+			var err error
+			cloudFormationTemplateUrl, err = response.GetTemplateUrl()
+			if err != nil {
+				diagnostics.AddError(
+					"Error Parsing Template URL",
+					err.Error(),
+				)
+			}
+		}
+
+		m.CloudFormationTemplateUrl = types.StringValue(cloudFormationTemplateUrl)
+	}
+
+	m.refreshCloudFormationStack(diagnostics, data.Automated.Link)
+
+	if m.TrackingGuid.IsUnknown() {
+		m.TrackingGuid = types.StringValue(data.Automated.TrackingGuid)
+	}
+
+	// Newly created templates remain pending until deployed in the cloud
+	// provider, at which point these values are populated by Read
+	if m.Status.IsUnknown() {
+		m.Status = types.StringValue(IntegrationStatusPending)
+	}
+	if m.AccountName.IsUnknown() {
+		m.AccountName = types.StringNull()
+	}
+	if m.CreationTime.IsUnknown() {
+		m.CreationTime = types.Int64Null()
+	}
+	if m.AutomatedDeploymentLink.IsUnknown() {
+		m.AutomatedDeploymentLink = types.StringValue(data.Automated.Link)
+	}
+	if m.ManualDeploymentLink.IsUnknown() {
+		m.ManualDeploymentLink = types.StringValue(data.Manual.TF_ARM)
+	}
+
+	if m.OutpostId.IsUnknown() {
+		m.OutpostId = types.StringNull()
+	}
+}
+
+// refreshCloudFormationStack populates the unknown CloudFormation stack
+// name and parameters from the automated deployment link of AWS templates.
+func (m *CloudIntegrationTemplateModel) refreshCloudFormationStack(diagnostics *diag.Diagnostics, link string) {
+	if !m.CloudFormationStackName.IsUnknown() && !m.CloudFormationStackParameters.IsUnknown() {
+		return
+	}
+
+	stackName := types.StringNull()
+	stackParameters := types.MapNull(types.StringType)
 	if m.CloudProvider.ValueString() == "AWS" {
-		cloudFormationTemplateUrl, err = response.GetTemplateUrl()
+		name, parameters, err := parseCloudFormationQuickCreateLink(link)
 		if err != nil {
 			diagnostics.AddError(
-				"Error Parsing Template URL",
+				"Error Parsing CloudFormation Stack Parameters",
 				err.Error(),
 			)
+		} else {
+			parameterValues := map[string]attr.Value{}
+			for key, value := range parameters {
+				parameterValues[key] = types.StringValue(value)
+			}
+
+			stackName = types.StringValue(name)
+			stackParameters = types.MapValueMust(types.StringType, parameterValues)
 		}
 	}
 
-	m.TrackingGuid = types.StringValue(data.Automated.TrackingGuid)
-	m.AutomatedDeploymentLink = types.StringValue(data.Automated.Link)
-	m.ManualDeploymentLink = types.StringValue(data.Manual.TF_ARM)
-	m.CloudFormationTemplateUrl = types.StringValue(cloudFormationTemplateUrl)
+	if m.CloudFormationStackName.IsUnknown() {
+		m.CloudFormationStackName = stackName
+	}
+	if m.CloudFormationStackParameters.IsUnknown() {
+		m.CloudFormationStackParameters = stackParameters
+	}
 }
 
-func (m *CloudIntegrationTemplateModel) RefreshConfiguredPropertyValues(ctx context.Context, diagnostics *diag.Diagnostics, response cloudonboarding.ListIntegrationInstancesResponse) {
-	// TODO: move this check outside?
-	if len(response.Reply.Data) == 0 || len(response.Reply.Data) > 1 {
+// DeploymentTemplateUnknown reports whether the deployment template
+// attribute of the configured cloud provider is unknown, meaning that the
+// template was regenerated and must be downloaded.
+func (m *CloudIntegrationTemplateModel) DeploymentTemplateUnknown() bool {
+	switch m.CloudProvider.ValueString() {
+	case enums.CloudProviderAzure.String():
+		return m.ArmTemplate.IsUnknown()
+	case enums.CloudProviderGCP.String():
+		return m.TerraformModule.IsUnknown()
+	}
+
+	return false
+}
+
+// RefreshDeploymentTemplate populates the downloaded content of the manual
+// deployment template into the attribute of the configured cloud provider.
+// Only unknown values are populated, and the attribute of any other cloud
+// provider is set to null.
+func (m *CloudIntegrationTemplateModel) RefreshDeploymentTemplate(content types.String) {
+	armTemplate := types.StringNull()
+	terraformModule := types.StringNull()
+	switch m.CloudProvider.ValueString() {
+	case enums.CloudProviderAzure.String():
+		armTemplate = content
+	case enums.CloudProviderGCP.String():
+		terraformModule = content
+	}
+
+	if m.ArmTemplate.IsUnknown() {
+		m.ArmTemplate = armTemplate
+	}
+	if m.TerraformModule.IsUnknown() {
+		m.TerraformModule = terraformModule
+	}
+}
+
+// parseCloudFormationQuickCreateLink returns the stack name and parameters
+// of an AWS CloudFormation console quick-create link. The console expects
+// the query string inside the URL fragment, e.g.
+// #/stacks/quickcreate?stackName=...&param_ExternalID=...
+func parseCloudFormationQuickCreateLink(link string) (string, map[string]string, error) {
+	parsedLink, err := url.Parse(link)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := parsedLink.Query()
+	if _, fragmentQuery, found := strings.Cut(parsedLink.Fragment, "?"); found {
+		values, err := url.ParseQuery(fragmentQuery)
+		if err != nil {
+			return "", nil, err
+		}
+
+		maps.Copy(query, values)
+	}
+
+	parameters := map[string]string{}
+	for key, values := range query {
+		if name, ok := strings.CutPrefix(key, "param_"); ok && len(values) > 0 {
+			parameters[name] = values[0]
+		}
+	}
+
+	return query.Get("stackName"), parameters, nil
+}
+
+func (m *CloudIntegrationTemplateModel) RefreshConfiguredPropertyValues(ctx context.Context, diagnostics *diag.Diagnostics, response cloudonboarding.ListIntegrationInstancesResponse, defaultTags map[string]string) {
+	if len(response.Reply.Data) > 1 {
 		m.Status = types.StringNull()
 		m.InstanceName = types.StringNull()
 		m.OutpostId = types.StringNull()
-
-		if len(response.Reply.Data) == 0 {
-			diagnostics.AddWarning(
-				"Integration Status Unknown",
-				"Unable to retrieve computed values for the following arguments "+
-					"from the Cortex Cloud API: status, instance_name, account_name, "+
-					"outpost_id, creation_time\n\n"+
-					"The provider will attempt to populate these arguments during "+
-					"the next terraform apply operation.",
-			)
-		} else if len(response.Reply.Data) > 1 {
-			diagnostics.AddWarning(
-				"Integration Status Unknown",
-				"Multiple values returned for the following arguments: "+
-					"status, instance_name, account_name, outpost_id, creation_time\n\n"+
-					"The provider will attempt to populate these arguments during "+
-					"the next terraform refresh or apply operation.",
-			)
-		}
+		m.AccountName = types.StringNull()
+		m.CreationTime = types.Int64Null()
+
+		diagnostics.AddWarning(
+			"Integration Status Unknown",
+			"Multiple values returned for the following arguments: "+
+				"status, instance_name, account_name, outpost_id, creation_time\n\n"+
+				"The provider will attempt to populate these arguments during "+
+				"the next terraform refresh or apply operation.",
+		)
 
 		return
 	}
@@ -200,10 +470,15 @@ func (m *CloudIntegrationTemplateModel) RefreshConfiguredPropertyValues(ctx cont
 
 	data := marshalledResponse[0]
 
+	// last_days only applies to the TAGS_MODIFIED_DAYS registry scanning
+	// type and is planned as null otherwise
+	if data.AdditionalCapabilities.RegistryScanningOptions.Type != enums.RegistryScanningTypeTagsModifiedDays.String() {
+		data.AdditionalCapabilities.RegistryScanningOptions.LastDays = nil
+	}
+
 	var (
 		additionalCapabilities  basetypes.ObjectValue
 		collectionConfiguration basetypes.ObjectValue
-		tags                    basetypes.SetValue
 		diags                   diag.Diagnostics
 	)
 
@@ -225,8 +500,12 @@ func (m *CloudIntegrationTemplateModel) RefreshConfiguredPropertyValues(ctx cont
 		collectionConfiguration = types.ObjectNull(m.CollectionConfiguration.AttributeTypes(ctx))
 	}
 
-	tags, diags = types.SetValueFrom(ctx, m.CustomResourcesTags.ElementType(ctx), data.CustomResourcesTags)
-	diagnostics.Append(diags...)
+	m.refreshTags(ctx, diagnostics, data.CustomResourcesTags, defaultTags)
+	if diagnostics.HasError() {
+		return
+	}
+
+	m.refreshScopeModifications(ctx, diagnostics, data.ScopeModifications)
 	if diagnostics.HasError() {
 		return
 	}
@@ -239,10 +518,208 @@ func (m *CloudIntegrationTemplateModel) RefreshConfiguredPropertyValues(ctx cont
 		m.CollectionConfiguration = collectionConfiguration
 	}
 	// END TEMPORARY
-	m.CustomResourcesTags = tags
 	m.InstanceName = types.StringValue(data.InstanceName)
 	m.ScanMode = types.StringValue(data.Scan.ScanMethod)
+	if data.Scope != "" {
+		m.Scope = types.StringValue(data.Scope)
+	}
+	m.RefreshStatusPropertyValues(response.Reply.Data[0])
+	if data.OutpostId != "" {
+		m.OutpostId = types.StringValue(data.OutpostId)
+	} else {
+		m.OutpostId = types.StringNull()
+	}
+}
+
+// RefreshStatusPropertyValues populates the values that change as the
+// integration progresses through its lifecycle.
+func (m *CloudIntegrationTemplateModel) RefreshStatusPropertyValues(data cloudonboarding.IntegrationInstanceData) {
 	m.Status = types.StringValue(data.Status)
-	// TODO: add OutpostId to IntegrationInstance struct?
-	m.OutpostId = types.StringValue(response.Reply.Data[0].OutpostId)
+	if data.AccountName != "" {
+		m.AccountName = types.StringValue(data.AccountName)
+	} else {
+		m.AccountName = types.StringNull()
+	}
+	if data.CreationTime != 0 {
+		m.CreationTime = types.Int64Value(data.CreationTime)
+	} else {
+		m.CreationTime = types.Int64Null()
+	}
+}
+
+// accountDetailsRequest converts the configured account details to their
+// API representation, returning nil if they are not configured.
+func (m *CloudIntegrationTemplateModel) accountDetailsRequest(ctx context.Context, diagnostics *diag.Diagnostics) *cloudonboarding.AccountDetails {
+	if m.AccountDetails.IsNull() || m.AccountDetails.IsUnknown() {
+		return nil
+	}
+
+	var accountDetails AccountDetailsModel
+	diagnostics.Append(m.AccountDetails.As(ctx, &accountDetails, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	return &cloudonboarding.AccountDetails{
+		OrganizationId:        accountDetails.OrganizationId.ValueString(),
+		OrganizationalUnitIds: util.StringSetToStringArray(ctx, diagnostics, accountDetails.OrganizationalUnitIds),
+		FolderIds:             util.StringSetToStringArray(ctx, diagnostics, accountDetails.FolderIds),
+	}
+}
+
+// scopeModificationsRequest converts the configured scope modifications to
+// their API representation.
+func (m *CloudIntegrationTemplateModel) scopeModificationsRequest(ctx context.Context, diagnostics *diag.Diagnostics) cloudonboarding.ScopeModifications {
+	var result cloudonboarding.ScopeModifications
+	if m.ScopeModifications.IsNull() || m.ScopeModifications.IsUnknown() {
+		return result
+	}
+
+	var scopeModifications ScopeModificationsModel
+	diagnostics.Append(m.ScopeModifications.As(ctx, &scopeModifications, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return result
+	}
+
+	if v := scopeModifications.Accounts; v != nil {
+		result.Accounts = &cloudonboarding.ScopeModificationsAccounts{
+			Enabled:    v.Enabled.ValueBool(),
+			Type:       v.Type.ValueString(),
+			AccountIds: util.StringSetToStringArray(ctx, diagnostics, v.AccountIds),
+		}
+	}
+
+	if v := scopeModifications.Projects; v != nil {
+		result.Projects = &cloudonboarding.ScopeModificationsProjects{
+			Enabled:    v.Enabled.ValueBool(),
+			Type:       v.Type.ValueString(),
+			ProjectIds: util.StringSetToStringArray(ctx, diagnostics, v.ProjectIds),
+		}
+	}
+
+	if v := scopeModifications.Subscriptions; v != nil {
+		result.Subscriptions = &cloudonboarding.ScopeModificationsSubscriptions{
+			Enabled:         v.Enabled.ValueBool(),
+			Type:            v.Type.ValueString(),
+			SubscriptionIds: util.StringSetToStringArray(ctx, diagnostics, v.SubscriptionIds),
+		}
+	}
+
+	if v := scopeModifications.Regions; v != nil {
+		result.Regions = &cloudonboarding.ScopeModificationsRegions{
+			Enabled: v.Enabled.ValueBool(),
+			Type:    v.Type.ValueString(),
+			Regions: util.StringSetToStringArray(ctx, diagnostics, v.Regions),
+		}
+	}
+
+	return result
+}
+
+// refreshScopeModifications populates ScopeModifications with the scope
+// modifications returned by the API. Entries that are disabled in the API
+// response are only populated if they are also present in the current
+// value, so that unconfigured entries remain null.
+func (m *CloudIntegrationTemplateModel) refreshScopeModifications(ctx context.Context, diagnostics *diag.Diagnostics, data cloudonboarding.ScopeModifications) {
+	var prior ScopeModificationsModel
+	if !m.ScopeModifications.IsNull() && !m.ScopeModifications.IsUnknown() {
+		diagnostics.Append(m.ScopeModifications.As(ctx, &prior, basetypes.ObjectAsOptions{})...)
+		if diagnostics.HasError() {
+			return
+		}
+	}
+
+	var scopeModifications ScopeModificationsModel
+
+	if v := data.Accounts; v != nil && (v.Enabled || prior.Accounts != nil) {
+		var priorIds types.Set
+		if prior.Accounts != nil {
+			priorIds = prior.Accounts.AccountIds
+		}
+
+		scopeModifications.Accounts = &ScopeModificationsAccountsModel{
+			Enabled:    types.BoolValue(v.Enabled),
+			Type:       refreshScopeModificationType(v.Type),
+			AccountIds: refreshScopeModificationIds(ctx, diagnostics, v.AccountIds, priorIds),
+		}
+	}
+
+	if v := data.Projects; v != nil && (v.Enabled || prior.Projects != nil) {
+		var priorIds types.Set
+		if prior.Projects != nil {
+			priorIds = prior.Projects.ProjectIds
+		}
+
+		scopeModifications.Projects = &ScopeModificationsProjectsModel{
+			Enabled:    types.BoolValue(v.Enabled),
+			Type:       refreshScopeModificationType(v.Type),
+			ProjectIds: refreshScopeModificationIds(ctx, diagnostics, v.ProjectIds, priorIds),
+		}
+	}
+
+	if v := data.Subscriptions; v != nil && (v.Enabled || prior.Subscriptions != nil) {
+		var priorIds types.Set
+		if prior.Subscriptions != nil {
+			priorIds = prior.Subscriptions.SubscriptionIds
+		}
+
+		scopeModifications.Subscriptions = &ScopeModificationsSubscriptionsModel{
+			Enabled:         types.BoolValue(v.Enabled),
+			Type:            refreshScopeModificationType(v.Type),
+			SubscriptionIds: refreshScopeModificationIds(ctx, diagnostics, v.SubscriptionIds, priorIds),
+		}
+	}
+
+	if v := data.Regions; v != nil && (v.Enabled || prior.Regions != nil) {
+		var priorIds types.Set
+		if prior.Regions != nil {
+			priorIds = prior.Regions.Regions
+		}
+
+		scopeModifications.Regions = &ScopeModificationsRegionsModel{
+			Enabled: types.BoolValue(v.Enabled),
+			Type:    refreshScopeModificationType(v.Type),
+			Regions: refreshScopeModificationIds(ctx, diagnostics, v.Regions, priorIds),
+		}
+	}
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	// Leave scope_modifications unset if it was not configured and no
+	// scope modifications are enabled
+	if m.ScopeModifications.IsNull() && scopeModifications == (ScopeModificationsModel{}) {
+		return
+	}
+
+	scopeModificationsValue, diags := types.ObjectValueFrom(ctx, ScopeModificationsAttrTypes, scopeModifications)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	m.ScopeModifications = scopeModificationsValue
+}
+
+func refreshScopeModificationType(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// refreshScopeModificationIds returns the set of IDs returned by the API,
+// or a null set if the API returned no IDs and prior is null.
+func refreshScopeModificationIds(ctx context.Context, diagnostics *diag.Diagnostics, ids []string, prior types.Set) types.Set {
+	if len(ids) == 0 {
+		if prior.IsNull() {
+			return types.SetNull(types.StringType)
+		}
+
+		ids = []string{}
+	}
+
+	return util.StringArrayToStringSet(ctx, diagnostics, ids)
 }
@@ -0,0 +1,86 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package models
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseCloudFormationQuickCreateLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		link               string
+		expectedStackName  string
+		expectedParameters map[string]string
+		expectError        bool
+	}{
+		"fragment-query": {
+			link:              "https://console.aws.amazon.com/cloudformation/home?region=us-east-1#/stacks/quickcreate?templateURL=https%3A%2F%2Fexample.com%2Ftemplate.json&stackName=cortex-cloud&param_ExternalID=abc123&param_CortexRoleName=CortexRole",
+			expectedStackName: "cortex-cloud",
+			expectedParameters: map[string]string{
+				"ExternalID":     "abc123",
+				"CortexRoleName": "CortexRole",
+			},
+		},
+		"url-query": {
+			link:              "https://console.aws.amazon.com/cloudformation/home?stackName=cortex-cloud&param_ExternalID=abc123",
+			expectedStackName: "cortex-cloud",
+			expectedParameters: map[string]string{
+				"ExternalID": "abc123",
+			},
+		},
+		"fragment-overrides-url-query": {
+			link:               "https://console.aws.amazon.com/cloudformation/home?stackName=old#/stacks/quickcreate?stackName=new",
+			expectedStackName:  "new",
+			expectedParameters: map[string]string{},
+		},
+		"no-query": {
+			link:               "https://console.aws.amazon.com/cloudformation/home#/stacks",
+			expectedStackName:  "",
+			expectedParameters: map[string]string{},
+		},
+		"escaped-parameter-value": {
+			link:              "https://console.aws.amazon.com/cloudformation/home#/stacks/quickcreate?stackName=cortex&param_Tags=a%3Db%2Cc%3Dd",
+			expectedStackName: "cortex",
+			expectedParameters: map[string]string{
+				"Tags": "a=b,c=d",
+			},
+		},
+		"invalid-url": {
+			link:        "https://console.aws.amazon.com/%zz",
+			expectError: true,
+		},
+		"invalid-fragment-query": {
+			link:        "https://console.aws.amazon.com/cloudformation/home#/stacks/quickcreate?stackName=%zz",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stackName, parameters, err := parseCloudFormationQuickCreateLink(testCase.link)
+			if testCase.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if stackName != testCase.expectedStackName {
+				t.Errorf("expected stack name %q, got %q", testCase.expectedStackName, stackName)
+			}
+
+			if !maps.Equal(parameters, testCase.expectedParameters) {
+				t.Errorf("expected parameters %v, got %v", testCase.expectedParameters, parameters)
+			}
+		})
+	}
+}
@@ -1,18 +1,101 @@
 package models
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// ManagedByTagKey is the key of the tag that Cortex Cloud applies to every
+// resource it creates in the cloud environment.
+const ManagedByTagKey = "managed_by"
+
+// Statuses reported by the API for cloud integration instances, as
+// documented for the status field of the list instances endpoint.
+const (
+	// IntegrationStatusPending is the status of integrations whose
+	// template has not been deployed in the cloud provider yet.
+	IntegrationStatusPending = "PENDING"
+
+	// IntegrationStatusConnected is the status of integrations whose
+	// cloud environment is connected and healthy.
+	IntegrationStatusConnected = "CONNECTED"
+
+	// IntegrationStatusWarning is the status of connected integrations
+	// with degraded permissions or capabilities.
+	IntegrationStatusWarning = "WARNING"
+
+	// IntegrationStatusError is the status of integrations that failed to
+	// connect to the cloud environment.
+	IntegrationStatusError = "ERROR"
+
+	// IntegrationStatusDisabled is the status of integrations that were
+	// disabled in the Cortex Cloud console.
+	IntegrationStatusDisabled = "DISABLED"
+)
+
+// Fields that integration instances can be searched by when listing them.
+const (
+	// IntegrationSearchFieldId matches the ID of the integration, which is
+	// also the tracking GUID of the template used to create it.
+	IntegrationSearchFieldId           = "ID"
+	IntegrationSearchFieldInstanceName = "INSTANCE_NAME"
+	IntegrationSearchFieldCloudType    = "CLOUD_TYPE"
+	IntegrationSearchFieldStatus       = "STATUS"
+	IntegrationSearchFieldScanMode     = "SCAN_MODE"
+	IntegrationSearchFieldScope        = "SCOPE"
+	IntegrationSearchFieldAccountName  = "ACCOUNT_NAME"
+	IntegrationSearchFieldAccountId    = "ACCOUNT_ID"
+)
+
+// AllIntegrationSearchFields returns the fields that integration instances
+// can be searched by.
+func AllIntegrationSearchFields() []string {
+	return []string{
+		IntegrationSearchFieldId,
+		IntegrationSearchFieldInstanceName,
+		IntegrationSearchFieldCloudType,
+		IntegrationSearchFieldStatus,
+		IntegrationSearchFieldScanMode,
+		IntegrationSearchFieldScope,
+		IntegrationSearchFieldAccountName,
+		IntegrationSearchFieldAccountId,
+	}
+}
+
+// TagObjectType is the object type of the elements of the
+// custom_resources_tags and tags_all attributes.
+var TagObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"key":   types.StringType,
+		"value": types.StringType,
+	},
+}
+
+type AccountDetailsModel struct {
+	OrganizationId        types.String `tfsdk:"organization_id"`
+	OrganizationalUnitIds types.Set    `tfsdk:"organizational_unit_ids"`
+	FolderIds             types.Set    `tfsdk:"folder_ids"`
+}
+
+// AccountDetailsAttrTypes are the attribute types of the account_details
+// attribute.
+var AccountDetailsAttrTypes = map[string]attr.Type{
+	"organization_id":         types.StringType,
+	"organizational_unit_ids": types.SetType{ElemType: types.StringType},
+	"folder_ids":              types.SetType{ElemType: types.StringType},
+}
+
 type AdditionalCapabilitiesModel struct {
 	XsiamAnalytics                types.Bool                   `tfsdk:"xsiam_analytics"`
 	DataSecurityPostureManagement types.Bool                   `tfsdk:"data_security_posture_management"`
 	RegistryScanning              types.Bool                   `tfsdk:"registry_scanning"`
 	RegistryScanningOptions       RegistryScanningOptionsModel `tfsdk:"registry_scanning_options"`
+	ServerlessScanning            types.Bool                   `tfsdk:"serverless_scanning"`
 }
 
 type RegistryScanningOptionsModel struct {
-	Type types.String `tfsdk:"type"`
+	Type     types.String `tfsdk:"type"`
+	LastDays types.Int32  `tfsdk:"last_days"`
 }
 
 type CollectionConfigurationModel struct {
@@ -29,9 +112,51 @@ type TagModel struct {
 }
 
 type ScopeModificationsModel struct {
-	Regions ScopeModificationsRegionsModel `tfsdk:"regions"`
+	Accounts      *ScopeModificationsAccountsModel      `tfsdk:"accounts"`
+	Projects      *ScopeModificationsProjectsModel      `tfsdk:"projects"`
+	Subscriptions *ScopeModificationsSubscriptionsModel `tfsdk:"subscriptions"`
+	Regions       *ScopeModificationsRegionsModel       `tfsdk:"regions"`
+}
+
+type ScopeModificationsAccountsModel struct {
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Type       types.String `tfsdk:"type"`
+	AccountIds types.Set    `tfsdk:"account_ids"`
+}
+
+type ScopeModificationsProjectsModel struct {
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Type       types.String `tfsdk:"type"`
+	ProjectIds types.Set    `tfsdk:"project_ids"`
+}
+
+type ScopeModificationsSubscriptionsModel struct {
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	Type            types.String `tfsdk:"type"`
+	SubscriptionIds types.Set    `tfsdk:"subscription_ids"`
 }
 
 type ScopeModificationsRegionsModel struct {
-	Enabled types.Bool `tfsdk:"enabled"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Type    types.String `tfsdk:"type"`
+	Regions types.Set    `tfsdk:"regions"`
+}
+
+// ScopeModificationAttrTypes returns the attribute types of a
+// scope_modifications entry whose set of IDs is named idsAttribute.
+func ScopeModificationAttrTypes(idsAttribute string) map[string]attr.Type {
+	return map[string]attr.Type{
+		"enabled":    types.BoolType,
+		"type":       types.StringType,
+		idsAttribute: types.SetType{ElemType: types.StringType},
+	}
+}
+
+// ScopeModificationsAttrTypes are the attribute types of the
+// scope_modifications attribute.
+var ScopeModificationsAttrTypes = map[string]attr.Type{
+	"accounts":      types.ObjectType{AttrTypes: ScopeModificationAttrTypes("account_ids")},
+	"projects":      types.ObjectType{AttrTypes: ScopeModificationAttrTypes("project_ids")},
+	"subscriptions": types.ObjectType{AttrTypes: ScopeModificationAttrTypes("subscription_ids")},
+	"regions":       types.ObjectType{AttrTypes: ScopeModificationAttrTypes("regions")},
 }
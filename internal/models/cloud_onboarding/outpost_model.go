@@ -0,0 +1,166 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package models
+
+import (
+	"context"
+
+	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// *********************************************************
+// Structs
+// *********************************************************
+type OutpostModel struct {
+	CloudProvider             types.String   `tfsdk:"cloud_provider"`
+	CustomResourcesTags       types.Set      `tfsdk:"custom_resources_tags"`
+	TrackingGuid              types.String   `tfsdk:"tracking_guid"`
+	Region                    types.String   `tfsdk:"region"`
+	Status                    types.String   `tfsdk:"status"`
+	CreationTime              types.Int64    `tfsdk:"creation_time"`
+	AutomatedDeploymentLink   types.String   `tfsdk:"automated_deployment_link"`
+	ManualDeploymentLink      types.String   `tfsdk:"manual_deployment_link"`
+	CloudFormationTemplateUrl types.String   `tfsdk:"cloud_formation_template_url"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (m *OutpostModel) tags(ctx context.Context, diagnostics *diag.Diagnostics) []cloudonboarding.Tag {
+	var tags []cloudonboarding.Tag
+	if m.CustomResourcesTags.IsNull() || m.CustomResourcesTags.IsUnknown() {
+		return tags
+	}
+
+	diagnostics.Append(m.CustomResourcesTags.ElementsAs(ctx, &tags, false)...)
+
+	return tags
+}
+
+func (m *OutpostModel) ToCreateRequest(ctx context.Context, diagnostics *diag.Diagnostics) cloudonboarding.CreateOutpostTemplateRequest {
+	return cloudonboarding.CreateOutpostTemplateRequest{
+		RequestData: cloudonboarding.CreateOutpostTemplateRequestData{
+			CloudProvider:       m.CloudProvider.ValueString(),
+			CustomResourcesTags: m.tags(ctx, diagnostics),
+		},
+	}
+}
+
+func (m *OutpostModel) ToGetRequest(ctx context.Context, diagnostics *diag.Diagnostics) cloudonboarding.ListOutpostsRequest {
+	return ToOutpostLookupRequest(m.TrackingGuid.ValueString())
+}
+
+func (m *OutpostModel) ToUpdateRequest(ctx context.Context, diagnostics *diag.Diagnostics) cloudonboarding.EditOutpostRequest {
+	return cloudonboarding.EditOutpostRequest{
+		RequestData: cloudonboarding.EditOutpostRequestData{
+			OutpostId:           m.TrackingGuid.ValueString(),
+			CustomResourcesTags: m.tags(ctx, diagnostics),
+		},
+	}
+}
+
+func (m *OutpostModel) ToDeleteRequest(ctx context.Context, diagnostics *diag.Diagnostics) []string {
+	return []string{m.TrackingGuid.ValueString()}
+}
+
+// RefreshComputedPropertyValues populates the values generated by the API
+// when the outpost template is created or edited. Only unknown values are
+// populated, so that the values planned from the prior state are kept when
+// the outpost is updated.
+func (m *OutpostModel) RefreshComputedPropertyValues(diagnostics *diag.Diagnostics, response cloudonboarding.CreateTemplateOrEditIntegrationInstanceResponse) {
+	data := response.Reply
+
+	if m.TrackingGuid.IsUnknown() {
+		m.TrackingGuid = types.StringValue(data.Automated.TrackingGuid)
+	}
+	if m.AutomatedDeploymentLink.IsUnknown() {
+		m.AutomatedDeploymentLink = types.StringValue(data.Automated.Link)
+	}
+	if m.ManualDeploymentLink.IsUnknown() {
+		m.ManualDeploymentLink = types.StringValue(data.Manual.TF_ARM)
+	}
+	if m.CloudFormationTemplateUrl.IsUnknown() {
+		cloudFormationTemplateUrl := ""
+		if m.CloudProvider.ValueString() == "AWS" {
+			var err error
+			cloudFormationTemplateUrl, err = response.GetTemplateUrl()
+			if err != nil {
+				diagnostics.AddError(
+					"Error Parsing Template URL",
+					err.Error(),
+				)
+			}
+		}
+
+		m.CloudFormationTemplateUrl = types.StringValue(cloudFormationTemplateUrl)
+	}
+
+	// Newly created outposts remain pending until the template is deployed
+	// in the cloud provider, at which point these values are populated by
+	// Read
+	if m.Status.IsUnknown() {
+		m.Status = types.StringValue(IntegrationStatusPending)
+	}
+	if m.Region.IsUnknown() {
+		m.Region = types.StringNull()
+	}
+	if m.CreationTime.IsUnknown() {
+		m.CreationTime = types.Int64Null()
+	}
+}
+
+func (m *OutpostModel) RefreshPropertyValues(ctx context.Context, diagnostics *diag.Diagnostics, outpost cloudonboarding.Outpost) {
+	m.TrackingGuid = types.StringValue(outpost.OutpostId)
+	m.CloudProvider = types.StringValue(outpost.CloudProvider)
+	m.Status = types.StringValue(outpost.Status)
+
+	if outpost.Region != "" {
+		m.Region = types.StringValue(outpost.Region)
+	} else {
+		m.Region = types.StringNull()
+	}
+
+	if outpost.CreatedAt != 0 {
+		m.CreationTime = types.Int64Value(outpost.CreatedAt)
+	} else {
+		m.CreationTime = types.Int64Null()
+	}
+
+	m.refreshTags(ctx, diagnostics, outpost.CustomResourcesTags)
+}
+
+// refreshTags populates CustomResourcesTags with the tags returned by the
+// API, excluding the Cortex-managed tag unless it is also present in the
+// current custom_resources_tags value. If the API returns no tags and none
+// are configured, the value is left null.
+func (m *OutpostModel) refreshTags(ctx context.Context, diagnostics *diag.Diagnostics, tags []cloudonboarding.Tag) {
+	configuredKeys := map[string]bool{}
+	for _, tag := range m.tags(ctx, diagnostics) {
+		configuredKeys[tag.Key] = true
+	}
+	if diagnostics.HasError() {
+		return
+	}
+
+	customTags := []cloudonboarding.Tag{}
+	for _, tag := range tags {
+		if configuredKeys[tag.Key] || tag.Key != ManagedByTagKey {
+			customTags = append(customTags, tag)
+		}
+	}
+
+	if len(customTags) == 0 && m.CustomResourcesTags.IsNull() {
+		return
+	}
+
+	customTagsValue, diags := types.SetValueFrom(ctx, TagObjectType, customTags)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	m.CustomResourcesTags = customTagsValue
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testTagSet(tags ...cloudonboarding.Tag) types.Set {
+	elements := []attr.Value{}
+	for _, tag := range tags {
+		elements = append(elements, types.ObjectValueMust(TagObjectType.AttrTypes, map[string]attr.Value{
+			"key":   types.StringValue(tag.Key),
+			"value": types.StringValue(tag.Value),
+		}))
+	}
+
+	return types.SetValueMust(TagObjectType, elements)
+}
+
+func TestOutpostModelRefreshPropertyValuesTags(t *testing.T) {
+	t.Parallel()
+
+	managedByTag := cloudonboarding.Tag{Key: ManagedByTagKey, Value: "paloaltonetworks"}
+	teamTag := cloudonboarding.Tag{Key: "team", Value: "platform"}
+	driftedTeamTag := cloudonboarding.Tag{Key: "team", Value: "security"}
+
+	testCases := map[string]struct {
+		current  types.Set
+		apiTags  []cloudonboarding.Tag
+		expected types.Set
+	}{
+		"unconfigured-no-tags": {
+			current:  types.SetNull(TagObjectType),
+			apiTags:  []cloudonboarding.Tag{managedByTag},
+			expected: types.SetNull(TagObjectType),
+		},
+		"unchanged": {
+			current:  testTagSet(teamTag),
+			apiTags:  []cloudonboarding.Tag{teamTag, managedByTag},
+			expected: testTagSet(teamTag),
+		},
+		"drifted-value": {
+			current:  testTagSet(teamTag),
+			apiTags:  []cloudonboarding.Tag{driftedTeamTag, managedByTag},
+			expected: testTagSet(driftedTeamTag),
+		},
+		"removed-outside-terraform": {
+			current:  testTagSet(teamTag),
+			apiTags:  []cloudonboarding.Tag{managedByTag},
+			expected: testTagSet(),
+		},
+		"added-outside-terraform": {
+			current:  types.SetNull(TagObjectType),
+			apiTags:  []cloudonboarding.Tag{teamTag},
+			expected: testTagSet(teamTag),
+		},
+		"configured-managed-by": {
+			current:  testTagSet(managedByTag),
+			apiTags:  []cloudonboarding.Tag{managedByTag},
+			expected: testTagSet(managedByTag),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diagnostics diag.Diagnostics
+			model := OutpostModel{CustomResourcesTags: testCase.current}
+			model.RefreshPropertyValues(context.Background(), &diagnostics, cloudonboarding.Outpost{
+				OutpostId:           "outpost-id",
+				CloudProvider:       "AWS",
+				CustomResourcesTags: testCase.apiTags,
+			})
+			if diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", diagnostics)
+			}
+
+			if !model.CustomResourcesTags.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, model.CustomResourcesTags)
+			}
+		})
+	}
+}
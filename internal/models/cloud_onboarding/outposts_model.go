@@ -0,0 +1,123 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package models
+
+import (
+	"context"
+
+	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// *********************************************************
+// Structs
+// *********************************************************
+type OutpostsModel struct {
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	Region        types.String `tfsdk:"region"`
+	Outposts      types.List   `tfsdk:"outposts"`
+}
+
+type OutpostSummaryModel struct {
+	Id            types.String `tfsdk:"id"`
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	Region        types.String `tfsdk:"region"`
+	Status        types.String `tfsdk:"status"`
+	Type          types.String `tfsdk:"type"`
+	CreationTime  types.Int64  `tfsdk:"creation_time"`
+}
+
+// OutpostSummaryObjectType is the object type of the elements of the
+// outposts attribute.
+var OutpostSummaryObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":             types.StringType,
+		"cloud_provider": types.StringType,
+		"region":         types.StringType,
+		"status":         types.StringType,
+		"type":           types.StringType,
+		"creation_time":  types.Int64Type,
+	},
+}
+
+// ToListRequest returns the request for the page of outposts matching the
+// configured cloud provider and region between the indexes from and to.
+func (m *OutpostsModel) ToListRequest(ctx context.Context, diagnostics *diag.Diagnostics, from, to int) cloudonboarding.ListOutpostsRequest {
+	andFilters := []cloudonboarding.Criteria{}
+	if !m.CloudProvider.IsNull() {
+		andFilters = append(andFilters, cloudonboarding.Criteria{
+			SearchField: "CLOUD_PROVIDER",
+			SearchType:  "EQ",
+			SearchValue: m.CloudProvider.ValueString(),
+		})
+	}
+	if !m.Region.IsNull() {
+		andFilters = append(andFilters, cloudonboarding.Criteria{
+			SearchField: "REGION",
+			SearchType:  "EQ",
+			SearchValue: m.Region.ValueString(),
+		})
+	}
+
+	return newListOutpostsRequest(andFilters, from, to)
+}
+
+// ToOutpostLookupRequest returns a request matching the outpost with the
+// given ID.
+func ToOutpostLookupRequest(outpostId string) cloudonboarding.ListOutpostsRequest {
+	return newListOutpostsRequest([]cloudonboarding.Criteria{
+		{
+			SearchField: "OUTPOST_ID",
+			SearchType:  "EQ",
+			SearchValue: outpostId,
+		},
+	}, 0, 1000)
+}
+
+func newListOutpostsRequest(andFilters []cloudonboarding.Criteria, from, to int) cloudonboarding.ListOutpostsRequest {
+	return cloudonboarding.ListOutpostsRequest{
+		RequestData: cloudonboarding.ListOutpostsRequestData{
+			FilterData: cloudonboarding.FilterData{
+				Filter: cloudonboarding.CriteriaFilter{
+					And: andFilters,
+				},
+				Paging: cloudonboarding.PagingFilter{
+					From: from,
+					To:   to,
+				},
+			},
+		},
+	}
+}
+
+func (m *OutpostsModel) RefreshPropertyValues(ctx context.Context, diagnostics *diag.Diagnostics, outposts []cloudonboarding.Outpost) {
+	summaries := make([]OutpostSummaryModel, 0, len(outposts))
+	for _, outpost := range outposts {
+		summary := OutpostSummaryModel{
+			Id:            types.StringValue(outpost.OutpostId),
+			CloudProvider: types.StringValue(outpost.CloudProvider),
+			Region:        types.StringValue(outpost.Region),
+			Status:        types.StringValue(outpost.Status),
+			Type:          types.StringValue(outpost.Type),
+			CreationTime:  types.Int64Null(),
+		}
+
+		if outpost.CreatedAt != 0 {
+			summary.CreationTime = types.Int64Value(outpost.CreatedAt)
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	outpostsValue, diags := types.ListValueFrom(ctx, OutpostSummaryObjectType, summaries)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	m.Outposts = outpostsValue
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"net/http"
+
 	sdk "github.com/mdboynton/cortex-cloud-go/api"
 	"github.com/mdboynton/cortex-cloud-go/appsec"
 	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
@@ -9,20 +11,36 @@ import (
 )
 
 type CortexCloudProviderModel struct {
-	ApiUrl               types.String `tfsdk:"api_url"`
-	ApiPort              types.Int32  `tfsdk:"api_port"`
-	ApiKey               types.String `tfsdk:"api_key"`
-	ApiKeyId             types.Int32  `tfsdk:"api_key_id"`
-	Insecure             types.Bool   `tfsdk:"insecure"`
-	RequestTimeout       types.Int32  `tfsdk:"request_timeout"`
-	RequestRetryInterval types.Int32  `tfsdk:"request_retry_interval"`
-	CrashStackDir        types.String `tfsdk:"crash_stack_dir"`
-	ConfigFile           types.String `tfsdk:"config_file"`
-	CheckEnvironment     types.Bool   `tfsdk:"check_environment"`
+	ApiUrl                    types.String `tfsdk:"api_url"`
+	ApiPort                   types.Int32  `tfsdk:"api_port"`
+	ApiKey                    types.String `tfsdk:"api_key"`
+	ApiKeyId                  types.Int32  `tfsdk:"api_key_id"`
+	Insecure                  types.Bool   `tfsdk:"insecure"`
+	RequestTimeout            types.Int32  `tfsdk:"request_timeout"`
+	RequestRetryInterval      types.Int32  `tfsdk:"request_retry_interval"`
+	RequestMaxRetries         types.Int32  `tfsdk:"request_max_retries"`
+	RequestRetryMaxDelay      types.Int32  `tfsdk:"request_retry_max_delay"`
+	RequestRetryJitter        types.Bool   `tfsdk:"request_retry_jitter"`
+	RequestRetryNonIdempotent types.Bool   `tfsdk:"request_retry_non_idempotent"`
+	CrashStackDir             types.String `tfsdk:"crash_stack_dir"`
+	ConfigFile                types.String `tfsdk:"config_file"`
+	ProfilesFile              types.String `tfsdk:"profiles_file"`
+	Profile                   types.String `tfsdk:"profile"`
+	CheckEnvironment          types.Bool   `tfsdk:"check_environment"`
+	DefaultTags               types.Object `tfsdk:"default_tags"`
+}
+
+type DefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
 }
 
 type CortexCloudSDKClients struct {
 	Config          sdk.Config
 	AppSec          *appsec.Client
 	CloudOnboarding *cloudonboarding.Client
+	DefaultTags     map[string]string
+
+	// HTTPClient downloads deployment artifacts that Cortex Cloud serves
+	// outside of the public API, such as generated templates.
+	HTTPClient *http.Client
 }
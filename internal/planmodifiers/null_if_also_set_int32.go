@@ -5,30 +5,32 @@ package planmodifiers
 
 import (
 	"context"
-	//"fmt"
+	"fmt"
 	"slices"
-	////"strconv"
-	//"strings"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
-//var (
-//	_ validator.String = NullIfAlsoSetPlanModifier{}
-//	_ validator.Bool   = NullIfAlsoSetPlanModifier{}
-//)
-
-func NullIfAlsoSetInt32(onValues []string) planmodifier.Int32 {
+// NullIfAlsoSetInt32 returns a plan modifier that sets the planned value of
+// an Int32 attribute to null if the String attribute matched by expression
+// is planned to be one of onValues. Relative path expressions are resolved
+// using the attribute being modified.
+//
+// This only changes the plan. Use validators.ConflictsWithOnStringValues to
+// reject configurations that set the attribute along with one of onValues.
+func NullIfAlsoSetInt32(onValues []string, expression path.Expression) planmodifier.Int32 {
 	return &nullIfAlsoSetInt32{
-		OnValues: onValues,
+		OnValues:       onValues,
+		PathExpression: expression,
 	}
 }
 
 type nullIfAlsoSetInt32 struct {
-	OnValues []string
+	OnValues       []string
+	PathExpression path.Expression
 }
 
 func (m *nullIfAlsoSetInt32) Description(ctx context.Context) string {
@@ -36,17 +38,29 @@ func (m *nullIfAlsoSetInt32) Description(ctx context.Context) string {
 }
 
 func (m *nullIfAlsoSetInt32) MarkdownDescription(context.Context) string {
-	return ""
+	return fmt.Sprintf("Value is set to null if %s is one of: %s", m.PathExpression, strings.Join(m.OnValues, ", "))
 }
 
 func (m *nullIfAlsoSetInt32) PlanModifyInt32(ctx context.Context, req planmodifier.Int32Request, resp *planmodifier.Int32Response) {
-	var attrValue basetypes.StringValue
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("additional_capabilities").AtName("registry_scanning_options").AtName("type"), &attrValue)...)
+	expression := req.PathExpression.Merge(m.PathExpression)
+
+	matchedPaths, diags := req.Plan.PathMatches(ctx, expression)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if slices.Contains(m.OnValues, attrValue.ValueString()) {
+	for _, matchedPath := range matchedPaths {
+		var matchedValue types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, matchedPath, &matchedValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if matchedValue.IsNull() || matchedValue.IsUnknown() || !slices.Contains(m.OnValues, matchedValue.ValueString()) {
+			continue
+		}
+
 		resp.PlanValue = types.Int32Null()
 	}
 }
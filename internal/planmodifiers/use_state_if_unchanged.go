@@ -0,0 +1,244 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planmodifiers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// UseStateIfUnchangedString returns a plan modifier that copies the prior
+// state value of a computed String attribute into the planned value if none
+// of the attributes matched by expressions are planned to change. Otherwise
+// the planned value is left unknown, as the attribute will be regenerated
+// during apply. Relative path expressions are resolved using the attribute
+// being modified.
+func UseStateIfUnchangedString(expressions ...path.Expression) planmodifier.String {
+	return &useStateIfUnchanged{
+		PathExpressions: expressions,
+	}
+}
+
+// UseStateIfUnchangedMap returns a plan modifier that copies the prior
+// state value of a computed Map attribute into the planned value if none of
+// the attributes matched by expressions are planned to change. Otherwise the
+// planned value is left unknown, as the attribute will be regenerated during
+// apply. Relative path expressions are resolved using the attribute being
+// modified.
+func UseStateIfUnchangedMap(expressions ...path.Expression) planmodifier.Map {
+	return &useStateIfUnchanged{
+		PathExpressions: expressions,
+	}
+}
+
+type useStateIfUnchanged struct {
+	PathExpressions path.Expressions
+}
+
+func (m *useStateIfUnchanged) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m *useStateIfUnchanged) MarkdownDescription(context.Context) string {
+	return fmt.Sprintf("Once set, the value of this attribute in state will not change unless one of the following attributes changes: %s", m.PathExpressions)
+}
+
+func (m *useStateIfUnchanged) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !m.shouldUseState(req.StateValue, req.PlanValue, req.ConfigValue) {
+		return
+	}
+
+	changed, diags := m.dependenciesChanged(ctx, req.PathExpression, req.Config, req.Plan, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || changed {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+func (m *useStateIfUnchanged) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	if !m.shouldUseState(req.StateValue, req.PlanValue, req.ConfigValue) {
+		return
+	}
+
+	changed, diags := m.dependenciesChanged(ctx, req.PathExpression, req.Config, req.Plan, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || changed {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+// shouldUseState reports whether the planned value is an unknown computed
+// value that may be replaced with a known prior state value.
+func (m *useStateIfUnchanged) shouldUseState(stateValue, planValue, configValue attr.Value) bool {
+	// Do nothing if there is no state value (resource creation)
+	if stateValue.IsNull() {
+		return false
+	}
+
+	// Do nothing if there is a known planned value
+	if !planValue.IsUnknown() {
+		return false
+	}
+
+	// Do nothing if there is an unknown configuration value, otherwise
+	// interpolation gets messed up
+	if configValue.IsUnknown() {
+		return false
+	}
+
+	return true
+}
+
+// dependenciesChanged reports whether any of the attributes matched by the
+// path expressions is planned to change. Configured values are compared
+// against the prior state, so that unknown computed children of partially
+// configured nested attributes do not count as changes. Attributes that are
+// not configured are compared using their planned value, unless it is
+// unknown, in which case the value is computed from other attributes and
+// does not affect the result.
+func (m *useStateIfUnchanged) dependenciesChanged(ctx context.Context, basePath path.Expression, config tfsdk.Config, plan tfsdk.Plan, state tfsdk.State) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for _, expression := range m.PathExpressions {
+		matchedPaths, matchDiags := plan.PathMatches(ctx, basePath.Merge(expression))
+		diags.Append(matchDiags...)
+		if diags.HasError() {
+			return false, diags
+		}
+
+		for _, matchedPath := range matchedPaths {
+			var configValue, planValue, stateValue attr.Value
+			diags.Append(config.GetAttribute(ctx, matchedPath, &configValue)...)
+			diags.Append(plan.GetAttribute(ctx, matchedPath, &planValue)...)
+			diags.Append(state.GetAttribute(ctx, matchedPath, &stateValue)...)
+			if diags.HasError() {
+				return false, diags
+			}
+
+			if valueChanged(configValue, planValue, stateValue) {
+				return true, diags
+			}
+		}
+	}
+
+	return false, diags
+}
+
+// valueChanged reports whether configValue differs from stateValue,
+// comparing nested attributes and elements recursively. If configValue is
+// null, the known planned value is compared instead. planValue may be nil
+// when the planned value cannot be matched to configValue, such as for set
+// elements.
+func valueChanged(configValue, planValue, stateValue attr.Value) bool {
+	if configValue == nil || configValue.IsUnknown() {
+		return true
+	}
+
+	if configValue.IsNull() {
+		if planValue == nil || planValue.IsUnknown() {
+			return false
+		}
+
+		return !planValue.Equal(stateValue)
+	}
+
+	if stateValue == nil || stateValue.IsNull() || stateValue.IsUnknown() {
+		return true
+	}
+
+	switch config := configValue.(type) {
+	case basetypes.ObjectValue:
+		state, ok := stateValue.(basetypes.ObjectValue)
+		if !ok {
+			return true
+		}
+
+		planAttributes := map[string]attr.Value{}
+		if plan, ok := planValue.(basetypes.ObjectValue); ok {
+			planAttributes = plan.Attributes()
+		}
+
+		for name, configAttribute := range config.Attributes() {
+			if valueChanged(configAttribute, planAttributes[name], state.Attributes()[name]) {
+				return true
+			}
+		}
+
+		return false
+	case basetypes.ListValue:
+		state, ok := stateValue.(basetypes.ListValue)
+		if !ok || len(config.Elements()) != len(state.Elements()) {
+			return true
+		}
+
+		var planElements []attr.Value
+		if plan, ok := planValue.(basetypes.ListValue); ok && len(plan.Elements()) == len(config.Elements()) {
+			planElements = plan.Elements()
+		}
+
+		for i, configElement := range config.Elements() {
+			var planElement attr.Value
+			if planElements != nil {
+				planElement = planElements[i]
+			}
+
+			if valueChanged(configElement, planElement, state.Elements()[i]) {
+				return true
+			}
+		}
+
+		return false
+	case basetypes.MapValue:
+		state, ok := stateValue.(basetypes.MapValue)
+		if !ok || len(config.Elements()) != len(state.Elements()) {
+			return true
+		}
+
+		planElements := map[string]attr.Value{}
+		if plan, ok := planValue.(basetypes.MapValue); ok {
+			planElements = plan.Elements()
+		}
+
+		for key, configElement := range config.Elements() {
+			stateElement, found := state.Elements()[key]
+			if !found || valueChanged(configElement, planElements[key], stateElement) {
+				return true
+			}
+		}
+
+		return false
+	case basetypes.SetValue:
+		state, ok := stateValue.(basetypes.SetValue)
+		if !ok || len(config.Elements()) != len(state.Elements()) {
+			return true
+		}
+
+		// Set elements are identified by their value, so each configured
+		// element is matched against any unchanged element in state
+		for _, configElement := range config.Elements() {
+			found := slices.ContainsFunc(state.Elements(), func(stateElement attr.Value) bool {
+				return !valueChanged(configElement, nil, stateElement)
+			})
+			if !found {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return !configValue.Equal(stateValue)
+}
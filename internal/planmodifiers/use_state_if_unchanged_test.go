@@ -0,0 +1,161 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package planmodifiers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testNestedObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name": types.StringType,
+		"id":   types.StringType,
+	},
+}
+
+func testNestedObject(name, id types.String) types.Object {
+	return types.ObjectValueMust(testNestedObjectType.AttrTypes, map[string]attr.Value{
+		"name": name,
+		"id":   id,
+	})
+}
+
+func TestValueChanged(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config   attr.Value
+		plan     attr.Value
+		state    attr.Value
+		expected bool
+	}{
+		"string-unchanged": {
+			config:   types.StringValue("a"),
+			plan:     types.StringValue("a"),
+			state:    types.StringValue("a"),
+			expected: false,
+		},
+		"string-changed": {
+			config:   types.StringValue("b"),
+			plan:     types.StringValue("b"),
+			state:    types.StringValue("a"),
+			expected: true,
+		},
+		"string-unknown-config": {
+			config:   types.StringUnknown(),
+			plan:     types.StringUnknown(),
+			state:    types.StringValue("a"),
+			expected: true,
+		},
+		"null-config-unknown-plan": {
+			config:   types.StringNull(),
+			plan:     types.StringUnknown(),
+			state:    types.StringValue("a"),
+			expected: false,
+		},
+		"null-config-removed": {
+			config:   types.StringNull(),
+			plan:     types.StringNull(),
+			state:    types.StringValue("a"),
+			expected: true,
+		},
+		"null-config-known-plan-unchanged": {
+			config:   types.StringNull(),
+			plan:     types.StringValue("a"),
+			state:    types.StringValue("a"),
+			expected: false,
+		},
+		"configured-null-state": {
+			config:   types.StringValue("a"),
+			plan:     types.StringValue("a"),
+			state:    types.StringNull(),
+			expected: true,
+		},
+		"object-unknown-computed-child": {
+			config:   testNestedObject(types.StringValue("a"), types.StringNull()),
+			plan:     testNestedObject(types.StringValue("a"), types.StringUnknown()),
+			state:    testNestedObject(types.StringValue("a"), types.StringValue("1")),
+			expected: false,
+		},
+		"object-configured-child-changed": {
+			config:   testNestedObject(types.StringValue("b"), types.StringNull()),
+			plan:     testNestedObject(types.StringValue("b"), types.StringUnknown()),
+			state:    testNestedObject(types.StringValue("a"), types.StringValue("1")),
+			expected: true,
+		},
+		"list-unknown-computed-child": {
+			config: types.ListValueMust(testNestedObjectType, []attr.Value{
+				testNestedObject(types.StringValue("a"), types.StringNull()),
+			}),
+			plan: types.ListValueMust(testNestedObjectType, []attr.Value{
+				testNestedObject(types.StringValue("a"), types.StringUnknown()),
+			}),
+			state: types.ListValueMust(testNestedObjectType, []attr.Value{
+				testNestedObject(types.StringValue("a"), types.StringValue("1")),
+			}),
+			expected: false,
+		},
+		"list-element-added": {
+			config: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("a"),
+				types.StringValue("b"),
+			}),
+			plan: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("a"),
+				types.StringValue("b"),
+			}),
+			state: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("a"),
+			}),
+			expected: true,
+		},
+		"map-unchanged": {
+			config:   types.MapValueMust(types.StringType, map[string]attr.Value{"k": types.StringValue("v")}),
+			plan:     types.MapValueMust(types.StringType, map[string]attr.Value{"k": types.StringValue("v")}),
+			state:    types.MapValueMust(types.StringType, map[string]attr.Value{"k": types.StringValue("v")}),
+			expected: false,
+		},
+		"map-key-changed": {
+			config:   types.MapValueMust(types.StringType, map[string]attr.Value{"x": types.StringValue("v")}),
+			plan:     types.MapValueMust(types.StringType, map[string]attr.Value{"x": types.StringValue("v")}),
+			state:    types.MapValueMust(types.StringType, map[string]attr.Value{"k": types.StringValue("v")}),
+			expected: true,
+		},
+		"set-unknown-computed-child": {
+			config: types.SetValueMust(testNestedObjectType, []attr.Value{
+				testNestedObject(types.StringValue("a"), types.StringNull()),
+				testNestedObject(types.StringValue("b"), types.StringNull()),
+			}),
+			plan: types.SetUnknown(testNestedObjectType),
+			state: types.SetValueMust(testNestedObjectType, []attr.Value{
+				testNestedObject(types.StringValue("b"), types.StringValue("2")),
+				testNestedObject(types.StringValue("a"), types.StringValue("1")),
+			}),
+			expected: false,
+		},
+		"set-element-changed": {
+			config: types.SetValueMust(testNestedObjectType, []attr.Value{
+				testNestedObject(types.StringValue("c"), types.StringNull()),
+			}),
+			plan: types.SetUnknown(testNestedObjectType),
+			state: types.SetValueMust(testNestedObjectType, []attr.Value{
+				testNestedObject(types.StringValue("a"), types.StringValue("1")),
+			}),
+			expected: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if changed := valueChanged(testCase.config, testCase.plan, testCase.state); changed != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// authTransport is an http.RoundTripper that adds the Cortex Cloud API
+// authentication headers to requests sent to the API host. Requests to any
+// other host, such as pre-signed storage links, are sent without
+// credentials.
+type authTransport struct {
+	base     http.RoundTripper
+	apiHost  string
+	apiKey   string
+	apiKeyId int
+}
+
+// newAuthTransport returns an authTransport that authenticates requests to
+// the host of apiUrl before passing them to base.
+func newAuthTransport(base http.RoundTripper, apiUrl string, apiKey string, apiKeyId int) *authTransport {
+	apiHost := ""
+	if parsedUrl, err := url.Parse(apiUrl); err == nil {
+		apiHost = parsedUrl.Hostname()
+	}
+
+	return &authTransport{
+		base:     base,
+		apiHost:  apiHost,
+		apiKey:   apiKey,
+		apiKeyId: apiKeyId,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.apiHost == "" || req.URL.Scheme != "https" || !strings.EqualFold(req.URL.Hostname(), t.apiHost) {
+		return t.base.RoundTrip(req)
+	}
+
+	// RoundTrip must not modify the original request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", t.apiKey)
+	req.Header.Set("x-xdr-auth-id", strconv.Itoa(t.apiKeyId))
+
+	return t.base.RoundTrip(req)
+}
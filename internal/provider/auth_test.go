@@ -0,0 +1,80 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAuthTransport(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		apiUrl       string
+		requestUrl   string
+		expectedAuth bool
+	}{
+		"api-host": {
+			apiUrl:       "https://api-example.xdr.us.paloaltonetworks.com",
+			requestUrl:   "https://api-example.xdr.us.paloaltonetworks.com/public_api/v1/templates/download",
+			expectedAuth: true,
+		},
+		"api-host-different-case": {
+			apiUrl:       "https://api-example.xdr.us.paloaltonetworks.com",
+			requestUrl:   "https://API-EXAMPLE.xdr.us.paloaltonetworks.com/download",
+			expectedAuth: true,
+		},
+		"other-host": {
+			apiUrl:       "https://api-example.xdr.us.paloaltonetworks.com",
+			requestUrl:   "https://storage.googleapis.com/bucket/template.tf",
+			expectedAuth: false,
+		},
+		"insecure-scheme": {
+			apiUrl:       "https://api-example.xdr.us.paloaltonetworks.com",
+			requestUrl:   "http://api-example.xdr.us.paloaltonetworks.com/download",
+			expectedAuth: false,
+		},
+		"empty-api-url": {
+			apiUrl:       "",
+			requestUrl:   "https://api-example.xdr.us.paloaltonetworks.com/download",
+			expectedAuth: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var sent *http.Request
+			base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				sent = req
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			})
+
+			req, err := http.NewRequest(http.MethodGet, testCase.requestUrl, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if _, err := newAuthTransport(base, testCase.apiUrl, "secret", 42).RoundTrip(req); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if req.Header.Get("Authorization") != "" {
+				t.Error("expected original request to be unmodified")
+			}
+
+			if hasAuth := sent.Header.Get("Authorization") == "secret" && sent.Header.Get("x-xdr-auth-id") == "42"; hasAuth != testCase.expectedAuth {
+				t.Errorf("expected authentication headers: %t, got headers %v", testCase.expectedAuth, sent.Header)
+			}
+		})
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/provider"
+	sdk "github.com/mdboynton/cortex-cloud-go/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	configFileEnvVar   = "CORTEX_TF_CONFIG_FILE"
+	profilesFileEnvVar = "CORTEX_TF_PROFILES_FILE"
+	profileEnvVar      = "CORTEX_TF_PROFILE"
+	checkEnvEnvVar     = "CORTEX_TF_CHECK_ENVIRONMENT"
+	defaultProfileName = "default"
+
+	defaultCheckEnvironment = false
+	defaultRequestTimeout   = 60
+	defaultCrashStackDir    = ""
+)
+
+// defaultProfilesFilePath returns the location of the shared profiles file
+// that is read when the profiles_file argument is not set.
+func defaultProfilesFilePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(homeDir, ".cortexcloud", "credentials")
+}
+
+// providerSettings contains the provider configuration values after
+// applying the precedence rules, along with a description of the source of
+// each value.
+type providerSettings struct {
+	CheckEnvironment bool
+
+	// ConfigFile is the path of the SDK configuration file. If set, the
+	// API URL, port and credentials are read from this file by the SDK
+	// instead of being resolved by the provider.
+	ConfigFile string
+
+	ApiUrl                    string
+	ApiPort                   int
+	ApiKey                    string
+	ApiKeyId                  int
+	Insecure                  bool
+	RequestTimeout            int
+	RequestRetryInterval      int
+	RequestMaxRetries         int
+	RequestRetryMaxDelay      int
+	RequestRetryJitter        bool
+	RequestRetryNonIdempotent bool
+	CrashStackDir             string
+
+	// Sources maps each argument name to the source of its value
+	Sources map[string]string
+}
+
+// SourceSummary returns a human-readable list of the source of each
+// setting, ordered by argument name.
+func (s providerSettings) SourceSummary() string {
+	names := make([]string, 0, len(s.Sources))
+	for name := range s.Sources {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n  - %s: %s", name, s.Sources[name])
+	}
+
+	return b.String()
+}
+
+// settingsResolver resolves provider settings using the following order of
+// precedence, from highest to lowest:
+//
+//  1. Provider block
+//  2. Environment variables (unless check_environment is false)
+//  3. Selected profile in the shared profiles file
+//  4. Default values
+type settingsResolver struct {
+	diagnostics   *diag.Diagnostics
+	checkEnv      bool
+	profile       map[string]string
+	profileSource string
+	sources       map[string]string
+}
+
+// resolveProviderSettings applies the precedence rules to the provider
+// configuration and returns the resulting settings.
+func resolveProviderSettings(ctx context.Context, config models.CortexCloudProviderModel, diagnostics *diag.Diagnostics) providerSettings {
+	r := &settingsResolver{
+		diagnostics: diagnostics,
+		checkEnv:    true,
+		sources:     map[string]string{},
+	}
+
+	// check_environment itself can only be set in the provider block or
+	// through its own environment variable
+	r.checkEnv = r.resolveBool("check_environment", config.CheckEnvironment, checkEnvEnvVar, defaultCheckEnvironment)
+
+	profilesFile := r.resolveString("profiles_file", config.ProfilesFile, profilesFileEnvVar, "")
+	profileName := r.resolveString("profile", config.Profile, profileEnvVar, "")
+	if diagnostics.HasError() {
+		return providerSettings{}
+	}
+
+	r.loadProfile(ctx, profilesFile, profileName)
+	if diagnostics.HasError() {
+		return providerSettings{}
+	}
+
+	settings := providerSettings{
+		CheckEnvironment:          r.checkEnv,
+		ConfigFile:                r.resolveString("config_file", config.ConfigFile, configFileEnvVar, ""),
+		Insecure:                  r.resolveBool("insecure", config.Insecure, "CORTEX_TF_INSECURE", false),
+		RequestTimeout:            r.resolveInt("request_timeout", config.RequestTimeout, "CORTEX_TF_REQUEST_TIMEOUT", defaultRequestTimeout),
+		RequestRetryInterval:      r.resolveInt("request_retry_interval", config.RequestRetryInterval, "CORTEX_TF_REQUEST_RETRY_INTERVAL", defaultRequestRetryInterval),
+		RequestMaxRetries:         r.resolveInt("request_max_retries", config.RequestMaxRetries, "CORTEX_TF_REQUEST_MAX_RETRIES", defaultRequestMaxRetries),
+		RequestRetryMaxDelay:      r.resolveInt("request_retry_max_delay", config.RequestRetryMaxDelay, "CORTEX_TF_REQUEST_RETRY_MAX_DELAY", defaultRequestRetryMaxDelay),
+		RequestRetryJitter:        r.resolveBool("request_retry_jitter", config.RequestRetryJitter, "CORTEX_TF_REQUEST_RETRY_JITTER", defaultRequestRetryJitter),
+		RequestRetryNonIdempotent: r.resolveBool("request_retry_non_idempotent", config.RequestRetryNonIdempotent, "CORTEX_TF_REQUEST_RETRY_NON_IDEMPOTENT", defaultRequestRetryNonIdempotent),
+		CrashStackDir:             r.resolveString("crash_stack_dir", config.CrashStackDir, "CORTEX_TF_CRASH_STACK_DIR", defaultCrashStackDir),
+	}
+
+	// The SDK configuration file takes the place of the connection
+	// arguments, as it did before profiles were introduced
+	if settings.ConfigFile != "" {
+		for _, name := range []string{"api_url", "api_port", "api_key", "api_key_id"} {
+			r.sources[name] = fmt.Sprintf("config file %s", settings.ConfigFile)
+		}
+	} else {
+		settings.ApiUrl = r.resolveString("api_url", config.ApiUrl, sdk.CORTEXCLOUD_API_URL_ENV_VAR, "")
+		settings.ApiPort = r.resolveInt("api_port", config.ApiPort, "", 0)
+		settings.ApiKey = r.resolveString("api_key", config.ApiKey, "CORTEX_API_KEY", "")
+		settings.ApiKeyId = r.resolveInt("api_key_id", config.ApiKeyId, "CORTEX_API_KEY_ID", 0)
+	}
+	settings.Sources = r.sources
+
+	// Values from environment variables and profiles are not checked by
+	// the schema validators, so validate the resolved values instead
+	settings.validate(diagnostics)
+
+	for name, source := range r.sources {
+		tflog.Debug(ctx, "Resolved provider argument", map[string]any{
+			"argument": name,
+			"source":   source,
+		})
+	}
+
+	return settings
+}
+
+// validate checks that the resolved settings are within bounds, regardless
+// of their source.
+func (s providerSettings) validate(diagnostics *diag.Diagnostics) {
+	atLeast := func(name string, value, minimum int) {
+		if value < minimum {
+			diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid Provider Configuration Value",
+				fmt.Sprintf("The value %d for argument %q from %s must be at least %d.", value, name, s.Sources[name], minimum),
+			)
+		}
+	}
+
+	atLeast("request_timeout", s.RequestTimeout, 0)
+	atLeast("request_retry_interval", s.RequestRetryInterval, 0)
+	atLeast("request_max_retries", s.RequestMaxRetries, 0)
+	atLeast("request_retry_max_delay", s.RequestRetryMaxDelay, 0)
+
+	if s.ApiPort < 0 || s.ApiPort > 65535 {
+		diagnostics.AddAttributeError(
+			path.Root("api_port"),
+			"Invalid Provider Configuration Value",
+			fmt.Sprintf("The value %d for argument \"api_port\" from %s must be between 0 and 65535.", s.ApiPort, s.Sources["api_port"]),
+		)
+	}
+}
+
+// loadProfile reads the named profile from the shared profiles file.
+//
+// If neither the profiles file nor the profile were explicitly selected, a
+// missing file or missing default profile is not an error.
+func (r *settingsResolver) loadProfile(ctx context.Context, profilesFile, profileName string) {
+	explicitFile := profilesFile != ""
+	explicitProfile := profileName != ""
+
+	if !explicitFile {
+		profilesFile = defaultProfilesFilePath()
+	}
+	if !explicitProfile {
+		profileName = defaultProfileName
+	}
+
+	if profilesFile == "" {
+		return
+	}
+
+	profiles, err := readProfiles(profilesFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicitFile && !explicitProfile {
+			tflog.Debug(ctx, "Shared profiles file not found, skipping profile lookup", map[string]any{
+				"profiles_file": profilesFile,
+			})
+			return
+		}
+
+		r.diagnostics.AddAttributeError(
+			path.Root("profiles_file"),
+			"Invalid Provider Profiles File",
+			fmt.Sprintf("Failed to read shared profiles file %q (%s): %s", profilesFile, r.sources["profiles_file"], err.Error()),
+		)
+		return
+	}
+
+	profile, ok := profiles[profileName]
+	if !ok {
+		if !explicitProfile {
+			return
+		}
+
+		r.diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Provider Profile Not Found",
+			fmt.Sprintf("Profile %q (%s) was not found in shared profiles file %q.", profileName, r.sources["profile"], profilesFile),
+		)
+		return
+	}
+
+	r.profile = profile
+	r.profileSource = fmt.Sprintf("profile %q in %s", profileName, profilesFile)
+}
+
+// lookup returns the raw value and source of a setting from the
+// environment or the selected profile, in order of precedence.
+func (r *settingsResolver) lookup(name, envVar string) (string, string, bool) {
+	if r.checkEnv && envVar != "" {
+		if value := os.Getenv(envVar); value != "" {
+			return value, fmt.Sprintf("environment variable %s", envVar), true
+		}
+	}
+
+	if value, ok := r.profile[name]; ok && value != "" {
+		return value, r.profileSource, true
+	}
+
+	return "", "", false
+}
+
+func (r *settingsResolver) resolveString(name string, value types.String, envVar string, defaultValue string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		r.sources[name] = "provider block"
+		return value.ValueString()
+	}
+
+	if raw, source, ok := r.lookup(name, envVar); ok {
+		r.sources[name] = source
+		return raw
+	}
+
+	r.sources[name] = "default"
+	return defaultValue
+}
+
+func (r *settingsResolver) resolveInt(name string, value types.Int32, envVar string, defaultValue int) int {
+	if !value.IsNull() && !value.IsUnknown() {
+		r.sources[name] = "provider block"
+		return int(value.ValueInt32())
+	}
+
+	if raw, source, ok := r.lookup(name, envVar); ok {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			r.addParseError(name, source, raw, "an integer")
+			return defaultValue
+		}
+
+		r.sources[name] = source
+		return parsed
+	}
+
+	r.sources[name] = "default"
+	return defaultValue
+}
+
+func (r *settingsResolver) resolveBool(name string, value types.Bool, envVar string, defaultValue bool) bool {
+	if !value.IsNull() && !value.IsUnknown() {
+		r.sources[name] = "provider block"
+		return value.ValueBool()
+	}
+
+	if raw, source, ok := r.lookup(name, envVar); ok {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			r.addParseError(name, source, raw, "a boolean")
+			return defaultValue
+		}
+
+		r.sources[name] = source
+		return parsed
+	}
+
+	r.sources[name] = "default"
+	return defaultValue
+}
+
+func (r *settingsResolver) addParseError(name, source, raw, expected string) {
+	r.diagnostics.AddAttributeError(
+		path.Root(name),
+		"Invalid Provider Configuration Value",
+		fmt.Sprintf("The value %q for argument %q from %s must be %s.", raw, name, source, expected),
+	)
+}
+
+// readProfiles parses an INI-style shared profiles file into a map of
+// profile names to their key/value pairs, e.g.:
+//
+//	[default]
+//	api_url    = https://api-example.xdr.us.paloaltonetworks.com
+//	api_key    = ...
+//	api_key_id = 1
+//
+// Lines beginning with "#" or ";" are treated as comments.
+func readProfiles(filePath string) (map[string]map[string]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = map[string]string{}
+			}
+			current = profiles[name]
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected \"key = value\"", lineNumber)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: value defined outside of a profile", lineNumber)
+		}
+
+		current[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
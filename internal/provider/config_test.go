@@ -0,0 +1,342 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/provider"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// writeTestFile writes contents to a file in a temporary directory and
+// returns its path.
+func writeTestFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(filePath, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write test file: %s", err)
+	}
+
+	return filePath
+}
+
+func TestReadProfiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		contents    string
+		expected    map[string]map[string]string
+		expectError bool
+	}{
+		"empty": {
+			contents: "",
+			expected: map[string]map[string]string{},
+		},
+		"multiple-profiles": {
+			contents: `
+# Comment
+[default]
+api_url = https://api-default.example.com
+api_key_id=1
+
+; Another comment
+[ staging ]
+api_url = "https://api-staging.example.com"
+api_key = 'secret'
+`,
+			expected: map[string]map[string]string{
+				"default": {
+					"api_url":    "https://api-default.example.com",
+					"api_key_id": "1",
+				},
+				"staging": {
+					"api_url": "https://api-staging.example.com",
+					"api_key": "secret",
+				},
+			},
+		},
+		"repeated-profile-merged": {
+			contents: `
+[default]
+api_url = https://api-default.example.com
+[default]
+api_key_id = 2
+`,
+			expected: map[string]map[string]string{
+				"default": {
+					"api_url":    "https://api-default.example.com",
+					"api_key_id": "2",
+				},
+			},
+		},
+		"value-with-equals-sign": {
+			contents: `
+[default]
+api_key = abc==
+`,
+			expected: map[string]map[string]string{
+				"default": {
+					"api_key": "abc==",
+				},
+			},
+		},
+		"empty-profile-name": {
+			contents:    "[]\napi_url = https://api.example.com\n",
+			expectError: true,
+		},
+		"value-outside-profile": {
+			contents:    "api_url = https://api.example.com\n",
+			expectError: true,
+		},
+		"missing-separator": {
+			contents:    "[default]\napi_url\n",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := readProfiles(writeTestFile(t, testCase.contents))
+			if testCase.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestReadProfilesMissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := readProfiles(filepath.Join(t.TempDir(), "missing"))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
+
+// nullProviderConfig returns a provider configuration with every argument
+// unset.
+func nullProviderConfig() models.CortexCloudProviderModel {
+	return models.CortexCloudProviderModel{
+		ApiUrl:                    types.StringNull(),
+		ApiPort:                   types.Int32Null(),
+		ApiKey:                    types.StringNull(),
+		ApiKeyId:                  types.Int32Null(),
+		Insecure:                  types.BoolNull(),
+		RequestTimeout:            types.Int32Null(),
+		RequestRetryInterval:      types.Int32Null(),
+		RequestMaxRetries:         types.Int32Null(),
+		RequestRetryMaxDelay:      types.Int32Null(),
+		RequestRetryJitter:        types.BoolNull(),
+		RequestRetryNonIdempotent: types.BoolNull(),
+		CrashStackDir:             types.StringNull(),
+		ConfigFile:                types.StringNull(),
+		ProfilesFile:              types.StringNull(),
+		Profile:                   types.StringNull(),
+		CheckEnvironment:          types.BoolNull(),
+		DefaultTags:               types.ObjectNull(nil),
+	}
+}
+
+// Environment variables are process-wide, so these tests cannot run in
+// parallel.
+func TestResolveProviderSettings(t *testing.T) {
+	profilesFile := writeTestFile(t, `
+[default]
+api_url = https://api-default.example.com
+request_timeout = 30
+
+[staging]
+api_url = https://api-staging.example.com
+api_key = profile-key
+request_max_retries = 5
+request_retry_interval = -1
+`)
+
+	testCases := map[string]struct {
+		configure         func(*models.CortexCloudProviderModel)
+		env               map[string]string
+		expectError       bool
+		expectedApiUrl    string
+		expectedApiKey    string
+		expectedTimeout   int
+		expectedRetries   int
+		expectedCheckEnv  bool
+		expectedSourceKey string
+		expectedSource    string
+	}{
+		"defaults": {
+			configure:         func(c *models.CortexCloudProviderModel) {},
+			expectedTimeout:   defaultRequestTimeout,
+			expectedRetries:   defaultRequestMaxRetries,
+			expectedSourceKey: "api_url",
+			expectedSource:    "default",
+		},
+		"environment-ignored-by-default": {
+			configure: func(c *models.CortexCloudProviderModel) {},
+			env: map[string]string{
+				"CORTEX_API_URL": "https://api-env.example.com",
+			},
+			expectedTimeout:   defaultRequestTimeout,
+			expectedRetries:   defaultRequestMaxRetries,
+			expectedSourceKey: "api_url",
+			expectedSource:    "default",
+		},
+		"environment-enabled-by-variable": {
+			configure: func(c *models.CortexCloudProviderModel) {},
+			env: map[string]string{
+				checkEnvEnvVar:   "true",
+				"CORTEX_API_URL": "https://api-env.example.com",
+			},
+			expectedApiUrl:    "https://api-env.example.com",
+			expectedTimeout:   defaultRequestTimeout,
+			expectedRetries:   defaultRequestMaxRetries,
+			expectedCheckEnv:  true,
+			expectedSourceKey: "api_url",
+			expectedSource:    "environment variable CORTEX_API_URL",
+		},
+		"default-profile": {
+			configure: func(c *models.CortexCloudProviderModel) {
+				c.ProfilesFile = types.StringValue(profilesFile)
+			},
+			expectedApiUrl:    "https://api-default.example.com",
+			expectedTimeout:   30,
+			expectedRetries:   defaultRequestMaxRetries,
+			expectedSourceKey: "request_timeout",
+			expectedSource:    `profile "default" in ` + profilesFile,
+		},
+		"provider-block-overrides-environment-and-profile": {
+			configure: func(c *models.CortexCloudProviderModel) {
+				c.CheckEnvironment = types.BoolValue(true)
+				c.ProfilesFile = types.StringValue(profilesFile)
+				c.ApiUrl = types.StringValue("https://api-block.example.com")
+			},
+			env: map[string]string{
+				"CORTEX_API_URL": "https://api-env.example.com",
+			},
+			expectedApiUrl:    "https://api-block.example.com",
+			expectedTimeout:   30,
+			expectedRetries:   defaultRequestMaxRetries,
+			expectedCheckEnv:  true,
+			expectedSourceKey: "api_url",
+			expectedSource:    "provider block",
+		},
+		"environment-overrides-profile": {
+			configure: func(c *models.CortexCloudProviderModel) {
+				c.CheckEnvironment = types.BoolValue(true)
+				c.ProfilesFile = types.StringValue(profilesFile)
+			},
+			env: map[string]string{
+				"CORTEX_TF_REQUEST_TIMEOUT": "90",
+			},
+			expectedApiUrl:    "https://api-default.example.com",
+			expectedTimeout:   90,
+			expectedRetries:   defaultRequestMaxRetries,
+			expectedCheckEnv:  true,
+			expectedSourceKey: "request_timeout",
+			expectedSource:    "environment variable CORTEX_TF_REQUEST_TIMEOUT",
+		},
+		"resolved-value-out-of-bounds": {
+			configure: func(c *models.CortexCloudProviderModel) {
+				c.ProfilesFile = types.StringValue(profilesFile)
+				c.Profile = types.StringValue("staging")
+			},
+			expectError: true,
+		},
+		"missing-explicit-profile": {
+			configure: func(c *models.CortexCloudProviderModel) {
+				c.ProfilesFile = types.StringValue(profilesFile)
+				c.Profile = types.StringValue("production")
+			},
+			expectError: true,
+		},
+		"invalid-environment-value": {
+			configure: func(c *models.CortexCloudProviderModel) {
+				c.CheckEnvironment = types.BoolValue(true)
+			},
+			env: map[string]string{
+				"CORTEX_TF_REQUEST_MAX_RETRIES": "many",
+			},
+			expectError: true,
+		},
+		"config-file-replaces-connection-arguments": {
+			configure: func(c *models.CortexCloudProviderModel) {
+				c.ConfigFile = types.StringValue("/etc/cortexcloud/config.json")
+				c.ProfilesFile = types.StringValue(profilesFile)
+			},
+			expectedTimeout:   30,
+			expectedRetries:   defaultRequestMaxRetries,
+			expectedSourceKey: "api_url",
+			expectedSource:    "config file /etc/cortexcloud/config.json",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Prevent the default profiles file of the user running the
+			// tests from being read
+			t.Setenv("HOME", t.TempDir())
+			for _, envVar := range []string{checkEnvEnvVar, "CORTEX_API_URL", "CORTEX_API_KEY", "CORTEX_TF_REQUEST_TIMEOUT", "CORTEX_TF_REQUEST_MAX_RETRIES", profileEnvVar, profilesFileEnvVar, configFileEnvVar} {
+				t.Setenv(envVar, "")
+			}
+			for envVar, value := range testCase.env {
+				t.Setenv(envVar, value)
+			}
+
+			config := nullProviderConfig()
+			testCase.configure(&config)
+
+			var diags diag.Diagnostics
+			got := resolveProviderSettings(context.Background(), config, &diags)
+
+			if testCase.expectError {
+				if !diags.HasError() {
+					t.Fatalf("expected error, got settings %+v", got)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got.ApiUrl != testCase.expectedApiUrl {
+				t.Errorf("expected api_url %q, got %q", testCase.expectedApiUrl, got.ApiUrl)
+			}
+			if got.ApiKey != testCase.expectedApiKey {
+				t.Errorf("expected api_key %q, got %q", testCase.expectedApiKey, got.ApiKey)
+			}
+			if got.RequestTimeout != testCase.expectedTimeout {
+				t.Errorf("expected request_timeout %d, got %d", testCase.expectedTimeout, got.RequestTimeout)
+			}
+			if got.RequestMaxRetries != testCase.expectedRetries {
+				t.Errorf("expected request_max_retries %d, got %d", testCase.expectedRetries, got.RequestMaxRetries)
+			}
+			if got.CheckEnvironment != testCase.expectedCheckEnv {
+				t.Errorf("expected check_environment %t, got %t", testCase.expectedCheckEnv, got.CheckEnvironment)
+			}
+			if source := got.Sources[testCase.expectedSourceKey]; source != testCase.expectedSource {
+				t.Errorf("expected %s source %q, got %q", testCase.expectedSourceKey, testCase.expectedSource, source)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sync"
+
+	"github.com/mdboynton/cortex-cloud-go/log"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ log.Logger = &redactingLogger{}
+)
+
+// sensitiveLogFieldKeys contains the keys of log fields whose values are
+// always masked, covering the authentication headers sent to the Cortex
+// Cloud API and the provider arguments marked as sensitive.
+var sensitiveLogFieldKeys = []string{
+	"api_key",
+	"api_key_id",
+	"Authorization",
+	"authorization",
+	"x-xdr-auth-id",
+	"X-Xdr-Auth-Id",
+}
+
+// sensitiveLogRegexes matches authentication headers and provider
+// credentials embedded in log messages and field values, such as request
+// and response dumps.
+var sensitiveLogRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(authorization|x-xdr-auth-id)(["']?\s*[:=]\s*\[?["']?)[^\s"'\],]+`),
+	regexp.MustCompile(`(?i)(api_key(?:_id)?)(["']?\s*[:=]\s*["']?)[^\s"',}]+`),
+}
+
+// redactingLogger is a log.Logger that masks credentials before passing log
+// entries to the wrapped logger.
+type redactingLogger struct {
+	base log.Logger
+
+	mu      sync.RWMutex
+	secrets []string
+}
+
+// newRedactingLogger returns a redactingLogger wrapping base.
+func newRedactingLogger(base log.Logger) *redactingLogger {
+	return &redactingLogger{
+		base: base,
+	}
+}
+
+// addSecrets configures the logger to mask every occurrence of the provided
+// secret values, in addition to the default masking rules. Empty values are
+// ignored.
+//
+// Every substring match is masked, so only values that are unlikely to occur
+// elsewhere in log entries, such as API keys, should be added. Short values
+// such as the API key ID are masked by the field key and header rules
+// instead.
+func (l *redactingLogger) addSecrets(secrets ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, secret := range secrets {
+		if secret != "" {
+			l.secrets = append(l.secrets, secret)
+		}
+	}
+}
+
+// maskContext returns a copy of ctx configured to mask sensitive values in
+// all log entries written with it.
+func (l *redactingLogger) maskContext(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogFieldKeys...)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, sensitiveLogRegexes...)
+	ctx = tflog.MaskMessageRegexes(ctx, sensitiveLogRegexes...)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.secrets) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, l.secrets...)
+		ctx = tflog.MaskMessageStrings(ctx, l.secrets...)
+	}
+
+	return ctx
+}
+
+// Trace implements log.Logger.
+func (l *redactingLogger) Trace(ctx context.Context, msg string, fields ...map[string]any) {
+	l.base.Trace(l.maskContext(ctx), msg, fields...)
+}
+
+// Debug implements log.Logger.
+func (l *redactingLogger) Debug(ctx context.Context, msg string, fields ...map[string]any) {
+	l.base.Debug(l.maskContext(ctx), msg, fields...)
+}
+
+// Info implements log.Logger.
+func (l *redactingLogger) Info(ctx context.Context, msg string, fields ...map[string]any) {
+	l.base.Info(l.maskContext(ctx), msg, fields...)
+}
+
+// Warn implements log.Logger.
+func (l *redactingLogger) Warn(ctx context.Context, msg string, fields ...map[string]any) {
+	l.base.Warn(l.maskContext(ctx), msg, fields...)
+}
+
+// Error implements log.Logger.
+func (l *redactingLogger) Error(ctx context.Context, msg string, fields ...map[string]any) {
+	l.base.Error(l.maskContext(ctx), msg, fields...)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// testTflogLogger is a log.Logger that writes log entries with tflog.
+type testTflogLogger struct{}
+
+func (testTflogLogger) Trace(ctx context.Context, msg string, fields ...map[string]any) {
+	tflog.Trace(ctx, msg, fields...)
+}
+
+func (testTflogLogger) Debug(ctx context.Context, msg string, fields ...map[string]any) {
+	tflog.Debug(ctx, msg, fields...)
+}
+
+func (testTflogLogger) Info(ctx context.Context, msg string, fields ...map[string]any) {
+	tflog.Info(ctx, msg, fields...)
+}
+
+func (testTflogLogger) Warn(ctx context.Context, msg string, fields ...map[string]any) {
+	tflog.Warn(ctx, msg, fields...)
+}
+
+func (testTflogLogger) Error(ctx context.Context, msg string, fields ...map[string]any) {
+	tflog.Error(ctx, msg, fields...)
+}
+
+func TestRedactingLogger(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		secrets          []string
+		msg              string
+		fields           map[string]any
+		expectedMsg      string
+		expectedFields   map[string]any
+		unexpectedOutput []string
+	}{
+		"authorization-header-in-message": {
+			msg:              "request headers: Authorization: secret-key x-xdr-auth-id: 3",
+			expectedMsg:      "request headers: *** ***",
+			unexpectedOutput: []string{"secret-key"},
+		},
+		"authorization-header-in-dump": {
+			msg:              `{"headers": {"Authorization": ["secret-key"], "X-Xdr-Auth-Id": ["42"]}}`,
+			unexpectedOutput: []string{"secret-key", `"42"`},
+		},
+		"api-key-argument-in-message": {
+			msg:              "resolved api_key=secret-key api_key_id=42",
+			unexpectedOutput: []string{"secret-key", "=42"},
+		},
+		"sensitive-field-keys": {
+			msg: "configuring provider",
+			fields: map[string]any{
+				"api_key":       "secret-key",
+				"api_key_id":    42,
+				"Authorization": "secret-key",
+				"api_url":       "https://api.example.com",
+			},
+			expectedMsg: "configuring provider",
+			expectedFields: map[string]any{
+				"api_key":       "***",
+				"api_key_id":    "***",
+				"Authorization": "***",
+				"api_url":       "https://api.example.com",
+			},
+		},
+		"secret-values": {
+			secrets:          []string{"resolved-secret-key"},
+			msg:              "request body contains resolved-secret-key",
+			fields:           map[string]any{"body": "key=resolved-secret-key"},
+			expectedMsg:      "request body contains ***",
+			expectedFields:   map[string]any{"body": "key=***"},
+			unexpectedOutput: []string{"resolved-secret-key"},
+		},
+		"short-values-not-masked": {
+			msg:            "retrying request in 3 seconds after status 503",
+			fields:         map[string]any{"attempt": "1"},
+			expectedMsg:    "retrying request in 3 seconds after status 503",
+			expectedFields: map[string]any{"attempt": "1"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			logger := newRedactingLogger(testTflogLogger{})
+			logger.addSecrets(testCase.secrets...)
+			logger.addSecrets("")
+
+			if testCase.fields != nil {
+				logger.Debug(ctx, testCase.msg, testCase.fields)
+			} else {
+				logger.Debug(ctx, testCase.msg)
+			}
+
+			for _, unexpected := range testCase.unexpectedOutput {
+				if strings.Contains(output.String(), unexpected) {
+					t.Errorf("expected %q to be masked, got %s", unexpected, output.String())
+				}
+			}
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			if err != nil {
+				t.Fatalf("unexpected error decoding log output: %s", err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("expected 1 log entry, got %d", len(entries))
+			}
+
+			if testCase.expectedMsg != "" && entries[0]["@message"] != testCase.expectedMsg {
+				t.Errorf("expected message %q, got %q", testCase.expectedMsg, entries[0]["@message"])
+			}
+
+			for key, expected := range testCase.expectedFields {
+				if entries[0][key] != expected {
+					t.Errorf("expected field %s to be %v, got %v", key, expected, entries[0][key])
+				}
+			}
+		})
+	}
+}
@@ -6,22 +6,31 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"slices"
+	"time"
 
 	cloudOnboardingDataSources "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/data_sources/cloud_onboarding"
+	cloudOnboardingModels "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/cloud_onboarding"
 	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/provider"
 	appSecResources "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/resources/application_security"
 	cloudOnboardingResources "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/resources/cloud_onboarding"
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/validators"
 	sdk "github.com/mdboynton/cortex-cloud-go/api"
 	"github.com/mdboynton/cortex-cloud-go/appsec"
 	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
 	"github.com/mdboynton/cortex-cloud-go/log"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -52,11 +61,16 @@ func (p *CortexCloudProvider) Schema(ctx context.Context, req provider.SchemaReq
 					"navigating to Settings > Configurations > Integrations > "+
 					"API Keys and clicking the \"Copy API URL\" button. Can "+
 					"also be configured using the `%s` environment "+
-					"variable.", sdk.CORTEXCLOUD_API_URL_ENV_VAR),
+					"variable if `check_environment` is `true`.", sdk.CORTEXCLOUD_API_URL_ENV_VAR),
 			},
 			"api_port": schema.Int32Attribute{
-				Optional:    true,
-				Description: "TODO",
+				Optional: true,
+				Description: "The port used to connect to the Cortex Cloud API. " +
+					"If omitted, the port is determined by the scheme of " +
+					"`api_url`.",
+				Validators: []validator.Int32{
+					int32validator.Between(0, 65535),
+				},
 			},
 			"api_key": schema.StringAttribute{
 				Optional:  true,
@@ -65,9 +79,8 @@ func (p *CortexCloudProvider) Schema(ctx context.Context, req provider.SchemaReq
 					"provider will use. You can create this from the Cortex Cloud " +
 					"console by navigating to Settings > Configurations > Integrations " +
 					"> API Keys. Can also be configured using the `CORTEX_API_KEY` " +
-					"environment variable. \n\nWARNING: If you are running the provider " +
-					"with Terraform with the `TF_LOG` environment variable set to `DEBUG`, " +
-					"the provider will output this value in the debug logs.",
+					"environment variable if `check_environment` is `true`. This " +
+					"value is redacted from the provider logs.",
 			},
 			"api_key_id": schema.Int32Attribute{
 				Optional:  true,
@@ -76,7 +89,8 @@ func (p *CortexCloudProvider) Schema(ctx context.Context, req provider.SchemaReq
 					"argument. You can retrieve this from the Cortex Cloud console " +
 					"by navigating to Settings > Configurations > Integrations > " +
 					"API Keys. Can also be configured using the `CORTEX_API_KEY_ID` " +
-					"environment variable.",
+					"environment variable if `check_environment` is `true`. This " +
+					"value is redacted from the provider logs.",
 			},
 			"insecure": schema.BoolAttribute{
 				Optional: true,
@@ -93,10 +107,60 @@ func (p *CortexCloudProvider) Schema(ctx context.Context, req provider.SchemaReq
 			},
 			"request_retry_interval": schema.Int32Attribute{
 				Optional: true,
-				Description: "Time (in seconds) to wait between API requests in " +
-					"the event of an HTTP 429 (Too Many Requests) response. If omitted, " +
-					"the default value is `3`. Can also be configured using the " +
-					"`CORTEX_TF_REQUEST_RETRY_INTERVAL` environment variable.",
+				Description: "Base time (in seconds) to wait before retrying an API " +
+					"request that received an HTTP 429 (Too Many Requests) or " +
+					"retryable 5xx response. The wait time doubles with each " +
+					"subsequent retry, up to `request_retry_max_delay`. If " +
+					"omitted, the default value is `3`. Can also be configured " +
+					"using the `CORTEX_TF_REQUEST_RETRY_INTERVAL` environment " +
+					"variable.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"request_max_retries": schema.Int32Attribute{
+				Optional: true,
+				Description: "Maximum number of times to retry an API request " +
+					"that received an HTTP 429 (Too Many Requests) or retryable 5xx " +
+					"response. HTTP 502, 503 and 504 responses are only retried " +
+					"for idempotent requests (e.g. `GET`), unless they include a " +
+					"`Retry-After` header or `request_retry_non_idempotent` is " +
+					"`true`. Set to `0` to disable retries. If omitted, the " +
+					"default value is `3`. Can also be configured using the " +
+					"`CORTEX_TF_REQUEST_MAX_RETRIES` environment variable.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"request_retry_max_delay": schema.Int32Attribute{
+				Optional: true,
+				Description: "Maximum time (in seconds) to wait between retries " +
+					"of an API request. This also caps any delay requested by the " +
+					"API in a `Retry-After` response header. If omitted, the " +
+					"default value is `30`. Can also be configured using the " +
+					"`CORTEX_TF_REQUEST_RETRY_MAX_DELAY` environment variable.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"request_retry_jitter": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether to randomize the time waited between " +
+					"retries of an API request, which prevents resources that are " +
+					"throttled at the same time from retrying at the same time. " +
+					"If omitted, the default value is `true`. Can also be " +
+					"configured using the `CORTEX_TF_REQUEST_RETRY_JITTER` " +
+					"environment variable.",
+			},
+			"request_retry_non_idempotent": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether to retry non-idempotent API requests " +
+					"(e.g. `POST` requests that create resources) that received " +
+					"an HTTP 502, 503 or 504 response. The API may have processed " +
+					"such a request before failing, so retrying it can create " +
+					"duplicate resources. If omitted, the default value is " +
+					"`false`. Can also be configured using the " +
+					"`CORTEX_TF_REQUEST_RETRY_NON_IDEMPOTENT` environment variable.",
 			},
 			"crash_stack_dir": schema.StringAttribute{
 				Optional: true,
@@ -111,12 +175,58 @@ func (p *CortexCloudProvider) Schema(ctx context.Context, req provider.SchemaReq
 					"using the `CORTEX_TF_CRASH_STACK_DIR` environment variable.",
 			},
 			"config_file": schema.StringAttribute{
-				Optional:    true,
-				Description: "TODO",
+				Optional: true,
+				Description: "Path to a Cortex Cloud SDK configuration file " +
+					"containing the API URL, port and credentials. If set, " +
+					"`api_url`, `api_port`, `api_key` and `api_key_id` are read " +
+					"from this file instead. Can also be configured using the " +
+					"`CORTEX_TF_CONFIG_FILE` environment variable.",
+			},
+			"profiles_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path to a shared profiles file containing one " +
+					"or more named profiles, each of which may define any of " +
+					"the other provider arguments (e.g. `api_url`, `api_key` " +
+					"and `api_key_id`). If omitted, the default value is " +
+					"`~/.cortexcloud/credentials`. Can also be configured using " +
+					"the `CORTEX_TF_PROFILES_FILE` environment variable.",
+			},
+			"profile": schema.StringAttribute{
+				Optional: true,
+				Description: "Name of the profile in the shared profiles " +
+					"file to read provider arguments from. If omitted, the " +
+					"`default` profile is used if present. Can also be " +
+					"configured using the `CORTEX_TF_PROFILE` environment " +
+					"variable.\n\nEach provider argument is resolved using the " +
+					"following order of precedence: the value in the provider " +
+					"block, then the value of the corresponding environment " +
+					"variable (if `check_environment` is `true`), then the value " +
+					"in the selected profile, then the default value.",
+			},
+			"default_tags": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "Tags that will be applied to every resource that " +
+					"supports custom resource tags, such as the " +
+					"`custom_resources_tags` argument of " +
+					"`cortexcloud_cloud_integration_template`. Tags configured " +
+					"on a resource override default tags with the same key.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Map of tag keys to tag values.",
+					},
+				},
 			},
 			"check_environment": schema.BoolAttribute{
-				Optional:    true,
-				Description: "TODO",
+				Optional: true,
+				Description: "Whether to read provider arguments that are not " +
+					"set in the provider block from their corresponding " +
+					"environment variables. If set to `false`, arguments are " +
+					"only read from the provider block, the configuration file " +
+					"and the shared profiles file. If omitted, the default value " +
+					"is `false`. Can also be configured using the " +
+					"`CORTEX_TF_CHECK_ENVIRONMENT` environment variable.",
 			},
 		},
 	}
@@ -130,6 +240,7 @@ func (p *CortexCloudProvider) Metadata(_ context.Context, _ provider.MetadataReq
 func (p *CortexCloudProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		cloudOnboardingResources.NewCloudIntegrationTemplateResource,
+		cloudOnboardingResources.NewOutpostResource,
 		appSecResources.NewApplicationSecurityRuleResource,
 	}
 }
@@ -137,22 +248,20 @@ func (p *CortexCloudProvider) Resources(ctx context.Context) []func() resource.R
 func (p *CortexCloudProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		cloudOnboardingDataSources.NewCloudIntegrationInstanceDataSource,
+		cloudOnboardingDataSources.NewCloudIntegrationInstancesDataSource,
+		cloudOnboardingDataSources.NewOutpostsDataSource,
 	}
 }
 
 func (p *CortexCloudProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	tflog.Debug(ctx, "Starting provider configuration")
 
-	// Set log level according to Terraform environment variables and print 
-	// warning message if debug logs are enabled
+	// Set log level according to Terraform environment variables
 	var logLevel string
 	if slices.ContainsFunc([]string{"DEBUG", "TRACE"}, func(s string) bool {
 		return s == os.Getenv("TF_LOG") || s == os.Getenv("TF_LOG_PROVIDER")
 	}) {
 		logLevel = "detailed"
-		tflog.Warn(ctx, "Debug logging enabled. Be aware that your API key "+
-						"and key ID will be visible in the provider log "+
-						"output!")
 	} else {
 		logLevel = "quiet"
 	}
@@ -164,44 +273,127 @@ func (p *CortexCloudProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
+	// Resolve each argument from the provider block, environment
+	// variables, shared profiles file or default value
+	settings := resolveProviderSettings(ctx, providerConfig, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Mask credentials in all log output, including the request and
+	// response dumps written by the SDK when debug logging is enabled. The
+	// SDK clients log through the redacting logger, and the transport
+	// masks the context of every API request. The API key is masked once
+	// the SDK client configuration has been resolved.
+	logger := newRedactingLogger(log.TflogAdapter{})
+	ctx = logger.maskContext(ctx)
+
+	// Build retry policy for API requests
+	policy := retryPolicy{
+		MaxRetries:   settings.RequestMaxRetries,
+		BaseInterval: time.Duration(settings.RequestRetryInterval) * time.Second,
+		MaxDelay:     time.Duration(settings.RequestRetryMaxDelay) * time.Second,
+		Jitter:       settings.RequestRetryJitter,
+
+		RetryNonIdempotent: settings.RequestRetryNonIdempotent,
+	}
+	transport := newRetryTransport(policy, settings.Insecure, logger)
+
+	clientOptions := []sdk.Option{
+		sdk.WithSkipVerifyCertificate(settings.Insecure),
+		sdk.WithTimeout(settings.RequestTimeout),
+		sdk.WithTransport(transport),
+		sdk.WithCrashStackDir(settings.CrashStackDir),
+		sdk.WithLogger(logger),
+		sdk.WithLogLevel(logLevel),
+	}
+
 	var (
 		clientConfig *sdk.Config
 		err          error
 	)
 
-	// If the config_file argument is defined, initialize SDK client config 
-	// using values stored in the provided file
-	if !providerConfig.ConfigFile.IsNull() && !providerConfig.ConfigFile.IsUnknown() {
-		configFile := providerConfig.ConfigFile.ValueString()
-
-		if configFile != "" {
-			clientConfig, err = sdk.NewConfigFromFile(configFile, providerConfig.CheckEnvironment.ValueBool())
+	// Environment variables have already been applied according to the
+	// precedence of the provider arguments, so the SDK does not need to
+	// check them again, which could override values from the provider
+	// block or profile.
+	//
+	// If the config_file argument is defined, initialize SDK client config
+	// using the connection values stored in the provided file
+	if settings.ConfigFile != "" {
+		clientConfig, err = sdk.NewConfigFromFile(settings.ConfigFile, false, clientOptions...)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("config_file"),
+				"Invalid Provider Configuration File",
+				fmt.Sprintf("Failed to read configuration file %q (%s): %s", settings.ConfigFile, settings.Sources["config_file"], err.Error()),
+			)
+			return
 		}
-	// Otherwise, configure SDK client using values from provider block
+	// Otherwise, configure SDK client using the resolved values
 	} else {
 		clientConfig = sdk.NewConfig(
-			providerConfig.ApiUrl.ValueString(),
-			providerConfig.ApiKey.ValueString(),
-			int(providerConfig.ApiKeyId.ValueInt32()),
-			providerConfig.CheckEnvironment.ValueBool(),
-			sdk.WithApiPort(int(providerConfig.ApiPort.ValueInt32())),
-			sdk.WithSkipVerifyCertificate(providerConfig.Insecure.ValueBool()),
-			sdk.WithTimeout(int(providerConfig.RequestTimeout.ValueInt32())),
-			//sdk.WithRetryMaxDelay(providerConfig.RetryMaxDelay),
-			sdk.WithCrashStackDir(providerConfig.CrashStackDir.ValueString()),
-			sdk.WithLogger(log.TflogAdapter{}),
-			sdk.WithLogLevel(logLevel),
+			settings.ApiUrl,
+			settings.ApiKey,
+			settings.ApiKeyId,
+			false,
+			append(clientOptions, sdk.WithApiPort(settings.ApiPort))...,
 		)
 	}
 
+	// Mask the resolved API key, which is only known to the SDK if it was
+	// read from the configuration file
+	logger.addSecrets(clientConfig.ApiKey)
+	ctx = logger.maskContext(ctx)
+
 	// Validate SDK client configuration
 	if err = clientConfig.Validate(); err != nil {
-		resp.Diagnostics.AddError("Cortex Cloud SDK Configuration Error", err.Error())
+		resp.Diagnostics.AddError(
+			"Cortex Cloud SDK Configuration Error",
+			fmt.Sprintf("%s\n\nProvider arguments were resolved from the following sources:%s", err.Error(), settings.SourceSummary()),
+		)
 		return
 	}
 
-	// Initialize SDK clients
-	clients := models.CortexCloudSDKClients{}
+	// Retrieve default tags
+	defaultTags := map[string]string{}
+	if !providerConfig.DefaultTags.IsNull() && !providerConfig.DefaultTags.IsUnknown() {
+		var defaultTagsConfig models.DefaultTagsModel
+		resp.Diagnostics.Append(providerConfig.DefaultTags.As(ctx, &defaultTagsConfig, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !defaultTagsConfig.Tags.IsNull() && !defaultTagsConfig.Tags.IsUnknown() {
+			resp.Diagnostics.Append(defaultTagsConfig.Tags.ElementsAs(ctx, &defaultTags, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		// The tag rules of the cloud provider are validated against the
+		// merged tags of each resource, as default tags apply to resources
+		// of every cloud provider
+		resp.Diagnostics.Append(validators.ValidateTagMap(
+			path.Root("default_tags").AtName("tags"),
+			defaultTags,
+			[]string{cloudOnboardingModels.ManagedByTagKey},
+			"",
+		)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Initialize SDK clients. Deployment artifacts are downloaded with the
+	// same retry and authentication settings as API requests
+	clients := models.CortexCloudSDKClients{
+		DefaultTags: defaultTags,
+		HTTPClient: &http.Client{
+			Transport: newAuthTransport(transport, clientConfig.ApiUrl, clientConfig.ApiKey, clientConfig.ApiKeyId),
+			Timeout:   time.Duration(settings.RequestTimeout) * time.Second,
+		},
+	}
 
 	appSecClient, err := appsec.NewClient(clientConfig)
 	if err != nil {
@@ -226,3 +418,4 @@ func (p *CortexCloudProvider) Configure(ctx context.Context, req provider.Config
 	resp.DataSourceData = &clients
 	resp.ResourceData = &clients
 }
+
@@ -0,0 +1,212 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultRequestMaxRetries    = 3
+	defaultRequestRetryInterval = 3
+	defaultRequestRetryMaxDelay = 30
+	defaultRequestRetryJitter   = true
+
+	defaultRequestRetryNonIdempotent = false
+)
+
+// retryPolicy defines how failed API requests are retried.
+type retryPolicy struct {
+	MaxRetries   int
+	BaseInterval time.Duration
+	MaxDelay     time.Duration
+	Jitter       bool
+
+	// RetryNonIdempotent allows requests using non-idempotent methods,
+	// such as POST, to be retried after a server error. Otherwise they are
+	// only retried if the API indicates that the request was not
+	// processed.
+	RetryNonIdempotent bool
+}
+
+// retryTransport is an http.RoundTripper that retries requests which
+// receive an HTTP 429 (Too Many Requests) or retryable 5xx response,
+// using exponential backoff bounded by the policy's maximum delay. See
+// retryPolicy.shouldRetry for the responses that are retried.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy retryPolicy
+	logger *redactingLogger
+}
+
+// newRetryTransport returns a retryTransport wrapping a clone of the
+// default HTTP transport, with certificate verification disabled if
+// insecure is true. The context of each request is masked using logger, so
+// that credentials are redacted from any log entries written with it.
+func newRetryTransport(policy retryPolicy, insecure bool, logger *redactingLogger) *retryTransport {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		base.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &retryTransport{
+		base:   base,
+		policy: policy,
+		logger: logger,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.logger.maskContext(req.Context())
+	req = req.WithContext(ctx)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+
+		// Requests with a body that cannot be replayed are not retried
+		canReplay := req.Body == nil || req.GetBody != nil
+		if !canReplay || !t.policy.shouldRetry(req.Method, resp) || attempt >= t.policy.MaxRetries {
+			return resp, nil
+		}
+
+		delay := t.policy.delay(attempt, resp.Header.Get("Retry-After"))
+
+		tflog.Warn(ctx, "Retrying Cortex Cloud API request", map[string]any{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"status_code": resp.StatusCode,
+			"attempt":     attempt + 1,
+			"max_retries": t.policy.MaxRetries,
+			"delay":       delay.String(),
+		})
+
+		// Drain and close the body so the underlying connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns the amount of time to wait before the next attempt. If the
+// API returned a valid Retry-After header, its value is used instead of the
+// computed backoff. In both cases the result is capped at the policy's
+// maximum delay.
+func (p retryPolicy) delay(attempt int, retryAfter string) time.Duration {
+	if d, ok := parseRetryAfter(retryAfter); ok {
+		return min(d, p.MaxDelay)
+	}
+
+	d := p.BaseInterval << attempt
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	// Apply "equal jitter" so that parallel requests throttled at the same
+	// time do not all retry at the same time
+	if p.Jitter && d > 0 {
+		half := d / 2
+		d = half + rand.N(half+1)
+	}
+
+	return d
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which may be
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// shouldRetry returns true if the request may succeed if sent again without
+// side effects.
+//
+// HTTP 429 (Too Many Requests) responses indicate that the API did not
+// process the request, so they are retried for every method. HTTP 502 (Bad
+// Gateway), 503 (Service Unavailable) and 504 (Gateway Timeout) responses
+// are retried for idempotent methods, or for any method if the response
+// includes a Retry-After header or the policy allows retrying
+// non-idempotent requests. Otherwise the API may have processed the request
+// (e.g. created a resource) before failing.
+func (p retryPolicy) shouldRetry(method string, resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !isRetryableServerError(resp.StatusCode) {
+		return false
+	}
+
+	if resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+
+	return p.RetryNonIdempotent || isIdempotentMethod(method)
+}
+
+// isRetryableServerError returns true if the status code indicates a
+// temporary failure of the API or of a gateway in front of it.
+func isRetryableServerError(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isIdempotentMethod returns true if sending a request with the given
+// method multiple times has the same effect as sending it once, as defined
+// in RFC 9110 section 9.2.2.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
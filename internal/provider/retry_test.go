@@ -0,0 +1,326 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{
+		MaxRetries:   5,
+		BaseInterval: 2 * time.Second,
+		MaxDelay:     10 * time.Second,
+	}
+
+	testCases := map[string]struct {
+		attempt    int
+		retryAfter string
+		expected   time.Duration
+	}{
+		"first-attempt": {
+			attempt:  0,
+			expected: 2 * time.Second,
+		},
+		"exponential-backoff": {
+			attempt:  2,
+			expected: 8 * time.Second,
+		},
+		"capped-at-max-delay": {
+			attempt:  3,
+			expected: 10 * time.Second,
+		},
+		"overflow-capped-at-max-delay": {
+			attempt:  70,
+			expected: 10 * time.Second,
+		},
+		"retry-after-seconds": {
+			attempt:    2,
+			retryAfter: "1",
+			expected:   1 * time.Second,
+		},
+		"retry-after-capped-at-max-delay": {
+			attempt:    0,
+			retryAfter: "120",
+			expected:   10 * time.Second,
+		},
+		"invalid-retry-after": {
+			attempt:    1,
+			retryAfter: "soon",
+			expected:   4 * time.Second,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := policy.delay(testCase.attempt, testCase.retryAfter)
+			if got != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{
+		BaseInterval: 4 * time.Second,
+		MaxDelay:     30 * time.Second,
+		Jitter:       true,
+	}
+
+	for range 100 {
+		got := policy.delay(1, "")
+		if got < 4*time.Second || got > 8*time.Second {
+			t.Fatalf("expected delay between 4s and 8s, got %s", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value      string
+		expected   time.Duration
+		expectedOk bool
+	}{
+		"empty": {
+			value:      "",
+			expectedOk: false,
+		},
+		"seconds": {
+			value:      "5",
+			expected:   5 * time.Second,
+			expectedOk: true,
+		},
+		"zero-seconds": {
+			value:      "0",
+			expected:   0,
+			expectedOk: true,
+		},
+		"negative-seconds": {
+			value:      "-1",
+			expectedOk: false,
+		},
+		"past-http-date": {
+			value:      "Wed, 21 Oct 2015 07:28:00 GMT",
+			expected:   0,
+			expectedOk: true,
+		},
+		"invalid": {
+			value:      "later",
+			expectedOk: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(testCase.value)
+			if ok != testCase.expectedOk {
+				t.Fatalf("expected ok to be %t, got %t", testCase.expectedOk, ok)
+			}
+			if got != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfterFutureDate(t *testing.T) {
+	t.Parallel()
+
+	value := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+
+	got, ok := parseRetryAfter(value)
+	if !ok {
+		t.Fatalf("expected %q to be parsed", value)
+	}
+	if got <= 0 || got > time.Minute {
+		t.Errorf("expected delay between 0 and 1m, got %s", got)
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		retryNonIdempotent bool
+		method             string
+		statusCode         int
+		retryAfter         string
+		expected           bool
+	}{
+		"ok": {
+			method:     http.MethodGet,
+			statusCode: http.StatusOK,
+			expected:   false,
+		},
+		"get-too-many-requests": {
+			method:     http.MethodGet,
+			statusCode: http.StatusTooManyRequests,
+			expected:   true,
+		},
+		"post-too-many-requests": {
+			method:     http.MethodPost,
+			statusCode: http.StatusTooManyRequests,
+			expected:   true,
+		},
+		"get-bad-gateway": {
+			method:     http.MethodGet,
+			statusCode: http.StatusBadGateway,
+			expected:   true,
+		},
+		"delete-gateway-timeout": {
+			method:     http.MethodDelete,
+			statusCode: http.StatusGatewayTimeout,
+			expected:   true,
+		},
+		"get-internal-server-error": {
+			method:     http.MethodGet,
+			statusCode: http.StatusInternalServerError,
+			expected:   false,
+		},
+		"get-not-implemented": {
+			method:     http.MethodGet,
+			statusCode: http.StatusNotImplemented,
+			expected:   false,
+		},
+		"post-service-unavailable": {
+			method:     http.MethodPost,
+			statusCode: http.StatusServiceUnavailable,
+			expected:   false,
+		},
+		"post-service-unavailable-retry-after": {
+			method:     http.MethodPost,
+			statusCode: http.StatusServiceUnavailable,
+			retryAfter: "10",
+			expected:   true,
+		},
+		"post-internal-server-error-retry-after": {
+			method:     http.MethodPost,
+			statusCode: http.StatusInternalServerError,
+			retryAfter: "10",
+			expected:   false,
+		},
+		"post-bad-gateway-retry-non-idempotent": {
+			retryNonIdempotent: true,
+			method:             http.MethodPost,
+			statusCode:         http.StatusBadGateway,
+			expected:           true,
+		},
+		"patch-bad-gateway": {
+			method:     http.MethodPatch,
+			statusCode: http.StatusBadGateway,
+			expected:   false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policy := retryPolicy{RetryNonIdempotent: testCase.retryNonIdempotent}
+			resp := &http.Response{
+				StatusCode: testCase.statusCode,
+				Header:     http.Header{},
+			}
+			if testCase.retryAfter != "" {
+				resp.Header.Set("Retry-After", testCase.retryAfter)
+			}
+
+			got := policy.shouldRetry(testCase.method, resp)
+			if got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryTransportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		method           string
+		statusCodes      []int
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		"get-retried-until-success": {
+			method:           http.MethodGet,
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		"get-retries-exhausted": {
+			method:           http.MethodGet,
+			statusCodes:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 3,
+		},
+		"post-not-retried-after-server-error": {
+			method:           http.MethodPost,
+			statusCodes:      []int{http.StatusBadGateway, http.StatusOK},
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+		"post-retried-after-throttling": {
+			method:           http.MethodPost,
+			statusCodes:      []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != "payload" {
+					t.Errorf("expected request body to be replayed, got %q", string(body))
+				}
+
+				attempt := attempts.Add(1)
+				w.WriteHeader(testCase.statusCodes[attempt-1])
+			}))
+			defer server.Close()
+
+			transport := newRetryTransport(retryPolicy{MaxRetries: 2}, false, newRedactingLogger(nil))
+
+			req, err := http.NewRequest(testCase.method, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != testCase.expectedStatus {
+				t.Errorf("expected status %d, got %d", testCase.expectedStatus, resp.StatusCode)
+			}
+			if got := attempts.Load(); got != testCase.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", testCase.expectedAttempts, got)
+			}
+		})
+	}
+}
@@ -6,15 +6,21 @@ package application_security
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/customtypes"
 	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/application_security"
 	providerModels "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/provider"
-	//"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/planmodifiers"
 	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/util"
 
 	"github.com/mdboynton/cortex-cloud-go/appsec"
 
 	"dario.cat/mergo"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,14 +30,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"gopkg.in/yaml.v3"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource               = &ApplicationSecurityRuleResource{}
-	_ resource.ResourceWithModifyPlan = &ApplicationSecurityRuleResource{}
+	_ resource.Resource                   = &ApplicationSecurityRuleResource{}
+	_ resource.ResourceWithModifyPlan     = &ApplicationSecurityRuleResource{}
+	_ resource.ResourceWithImportState    = &ApplicationSecurityRuleResource{}
+	_ resource.ResourceWithValidateConfig = &ApplicationSecurityRuleResource{}
 )
 
 // NewApplicationSecurityRuleResource is a helper function to simplify the provider implementation.
@@ -144,19 +152,64 @@ func (r *ApplicationSecurityRuleResource) Schema(ctx context.Context, req resour
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
-							Description: "TODO",
-							Required:    true,
+							Description: "The name of the IaC framework " +
+								"that the definition applies to. Must be one " +
+								"of `ARM`, `BICEP`, `CLOUDFORMATION`, " +
+								"`KUBERNETES`, `TERRAFORM` or `TERRAFORMPLAN`. " +
+								"The API implicitly applies `TERRAFORM` rules " +
+								"to `TERRAFORMPLAN` as well.",
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									models.AllAppSecRuleFrameworkNames()...,
+								),
+							},
 							PlanModifiers: []planmodifier.String{
 								stringplanmodifier.UseStateForUnknown(),
 							},
 						},
 						"definition": schema.StringAttribute{
 							Description: "TODO",
+							CustomType:  customtypes.YAMLStringType{},
+							Optional:    true,
+							Computed:    true,
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(
+									path.MatchRelative().AtParent().AtName("definition_block"),
+								),
+							},
+						},
+						"definition_block": schema.SingleNestedAttribute{
+							Description: "Structured alternative to `definition`. " +
+								"The provider serializes the block into the " +
+								"Checkov-style YAML definition expected by the " +
+								"API, which is exposed in `definition`. The API " +
+								"only returns YAML definitions, so the block is " +
+								"not populated when the rule is imported.",
 							Optional: true,
-							Computed: true,
-							//PlanModifiers: []planmodifier.String{
-							//	planmodifiers.AddFrameworkDefinitionMetadata(),
-							//},
+							Validators: []validator.Object{
+								objectvalidator.AtLeastOneOf(
+									path.MatchRelative().AtName("and"),
+									path.MatchRelative().AtName("or"),
+								),
+							},
+							Attributes: map[string]schema.Attribute{
+								"provider": schema.StringAttribute{
+									Description: "The provider of the resources " +
+										"evaluated by the definition, e.g. `aws`.",
+									Required: true,
+								},
+								"and": definitionConditionsAttribute(
+									"Conditions that must all be met.",
+									models.MaxDefinitionConditionDepth,
+									"or",
+								),
+								"or": definitionConditionsAttribute(
+									"Conditions of which at least one must be met.",
+									models.MaxDefinitionConditionDepth,
+									"and",
+								),
+							},
 						},
 						"definition_link": schema.StringAttribute{
 							Description: "TODO",
@@ -303,9 +356,60 @@ func (r *ApplicationSecurityRuleResource) Configure(ctx context.Context, req res
 	r.client = client.AppSec
 }
 
-// ModifyPlan modifies the planned state of the resource
+// ValidateConfig checks that each framework is configured once and that its
+// definition matches the syntax of the framework.
+func (r *ApplicationSecurityRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// The frameworks cannot be read into the model until they are known
+	var frameworksValue types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("frameworks"), &frameworksValue)...)
+	if resp.Diagnostics.HasError() || frameworksValue.IsNull() || frameworksValue.IsUnknown() {
+		return
+	}
+
+	var frameworks []models.FrameworkModel
+	resp.Diagnostics.Append(frameworksValue.ElementsAs(ctx, &frameworks, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configuredFrameworks := map[string]bool{}
+	for idx, framework := range frameworks {
+		if framework.Name.IsNull() || framework.Name.IsUnknown() {
+			continue
+		}
+
+		frameworkPath := path.Root("frameworks").AtListIndex(idx)
+		frameworkName := framework.Name.ValueString()
+
+		if configuredFrameworks[frameworkName] {
+			resp.Diagnostics.AddAttributeError(
+				frameworkPath.AtName("name"),
+				"Duplicate Framework",
+				fmt.Sprintf("The %s framework is configured more than once.", frameworkName),
+			)
+			continue
+		}
+		configuredFrameworks[frameworkName] = true
+
+		definition := framework.Definition
+		definitionPath := frameworkPath.AtName("definition")
+		if !framework.DefinitionBlock.IsNull() {
+			definition = framework.DefinitionBlockToYAML(ctx, &resp.Diagnostics, frameworkPath.AtName("definition_block"))
+			definitionPath = frameworkPath.AtName("definition_block")
+		}
+
+		if definition.IsNull() || definition.IsUnknown() {
+			continue
+		}
+
+		models.ValidateFrameworkDefinition(&resp.Diagnostics, definitionPath, frameworkName, definition.ValueString())
+	}
+}
+
+// ModifyPlan modifies the planned state of the resource by serializing any
+// structured framework definitions into YAML.
 //
-// NOTE: Because this resource's implementation of this function only serves
+// NOTE: Because this resource's implementation of this function mainly serves
 // to validate the YAML definitions for each of the configured frameworks, it
 // should *probably* be implemented in the ValidateConfiguration function
 // instead, but I haven't found a way to be able to make it work in there since
@@ -323,46 +427,56 @@ func (r *ApplicationSecurityRuleResource) ModifyPlan(ctx context.Context, req re
 		return
 	}
 
-	// Serialize framework YAML definitions
-	for _, framework := range plan.Frameworks {
-		yamlBytes, err := yaml.Marshal(framework.Definition.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Converting YAML",
-				err.Error(),
-			)
+	// Serialize structured framework definitions into YAML
+	for idx, framework := range plan.Frameworks {
+		if framework.DefinitionBlock.IsNull() {
+			continue
+		}
+
+		frameworkPath := path.Root("frameworks").AtListIndex(idx)
+		definition := framework.DefinitionBlockToYAML(ctx, &resp.Diagnostics, frameworkPath.AtName("definition_block"))
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		framework.Definition = types.StringValue(string(yamlBytes))
+		plan.Frameworks[idx].Definition = definition
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, frameworkPath.AtName("definition"), definition)...)
 	}
 
-	// Update frameworks attribute with serialized definitions
-	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("frameworks"), plan.Frameworks)...)
+	var state models.ApplicationSecurityRuleModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	//// If the resource already exists and the planned value of the frameworks
-	//// attribute is equal to the value in the state, then no validation needs
-	//// to occur
-	//// TODO: fix this
-	////if !req.State.Raw.IsNull() {
-	////	var state models.ApplicationSecurityRuleModel
-	////	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	////	if resp.Diagnostics.HasError() {
-	////		return
-	////	}
+		// Semantic equality is not applied to planned values, so keep the
+		// definitions in state that only differ from the planned
+		// definitions in formatting, e.g. after an import
+		r.planStateDefinitions(ctx, resp, &plan, state)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	////	if !state.Frameworks.IsNull() && plan.Frameworks.Equal(state.Frameworks) {
-	////		return
-	////	}
-	////}
+	// The API client is not available if the provider configuration
+	// depends on values that are unknown until apply
+	if r.client == nil {
+		return
+	}
 
-	//// Validate framework attribute definition against API
-	//validationRequest := plan.ToValidateRequest(ctx, &resp.Diagnostics)
-	//if resp.Diagnostics.HasError() {
-	//	return
-	//}
+	// If the resource already exists and the planned frameworks are equal
+	// to the frameworks in the state, then no validation needs to occur.
+	// The frameworks of default rules are never sent to the API, so they
+	// are not validated either.
+	if !req.State.Raw.IsNull() {
+		if !state.IsCustom.ValueBool() || slices.EqualFunc(plan.Frameworks, state.Frameworks, frameworkDefinitionsEqual) {
+			return
+		}
+	}
 
-	//appSecAPI.Validate(ctx, &resp.Diagnostics, r.client, validationRequest)
+	// Validate framework definitions against API
+	r.validateFrameworks(ctx, &resp.Diagnostics, plan.Frameworks)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -382,30 +496,10 @@ func (r *ApplicationSecurityRuleResource) Create(ctx context.Context, req resour
 		return
 	}
 
-	validateRequestData := []appsec.ValidateRequest{}
-	for _, framework := range createRequest.Frameworks {
-		validateRequestData = append(validateRequestData, appsec.ValidateRequest{
-			Framework: framework.Name,
-			Definition: framework.Definition,
-		})
-	}
-
-	validateResponse, err := r.client.Validate(ctx, validateRequestData)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Or Cloning Application Security Rule",
-			err.Error(),
-		)
-		return
-	}
-
-	if validateResponse.IsValid == nil || *validateResponse.IsValid == false {
-		resp.Diagnostics.AddError(
-			"Error Creating Or Cloning Application Security Rule",
-			fmt.Sprintf("rule validation failed: %+v", validateResponse),
-		)
+	// Validate framework definitions against API
+	r.validateFrameworks(ctx, &resp.Diagnostics, plan.Frameworks)
+	if resp.Diagnostics.HasError() {
 		return
-
 	}
 
 	// Create new resource
@@ -485,6 +579,15 @@ func (r *ApplicationSecurityRuleResource) Update(ctx context.Context, req resour
 		return
 	}
 
+	// Validate framework definitions against API. Only the labels of
+	// default rules are sent to the API, so their frameworks are skipped.
+	if plan.IsCustom.ValueBool() {
+		r.validateFrameworks(ctx, &resp.Diagnostics, plan.Frameworks)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Generate API create request body from plan
 	request := plan.ToUpdateRequest(ctx, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -532,3 +635,149 @@ func (r *ApplicationSecurityRuleResource) Delete(ctx context.Context, req resour
 		return
 	}
 }
+
+// definitionConditionsAttribute returns the schema of a list of
+// definition_block conditions, which may contain depth-1 further levels of
+// nested "and" and "or" conditions. The list conflicts with the sibling
+// attribute named conflictsWith.
+func definitionConditionsAttribute(description string, depth int, conflictsWith string) schema.ListNestedAttribute {
+	attributes := map[string]schema.Attribute{
+		"condition_type": schema.StringAttribute{
+			Description: "The type of the condition, e.g. `attribute` or " +
+				"`connection`. Required unless the condition contains " +
+				"nested `and` or `or` conditions.",
+			Optional: true,
+		},
+		"resource_types": schema.ListAttribute{
+			Description: "The resource types evaluated by the condition.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"attribute": schema.StringAttribute{
+			Description: "The resource attribute evaluated by the condition.",
+			Optional:    true,
+		},
+		"operator": schema.StringAttribute{
+			Description: "The operator used to evaluate the attribute, " +
+				"e.g. `equals` or `exists`.",
+			Optional: true,
+		},
+		"value": schema.StringAttribute{
+			Description: "The value the attribute is compared against. " +
+				"The value is parsed as YAML, so `true` and `10` are " +
+				"written as a boolean and a number. Quote the value, " +
+				"e.g. `\"\\\"10\\\"\"`, to compare against a string.",
+			Optional: true,
+		},
+	}
+
+	if depth > 1 {
+		attributes["and"] = definitionConditionsAttribute("Nested conditions that must all be met.", depth-1, "or")
+		attributes["or"] = definitionConditionsAttribute("Nested conditions of which at least one must be met.", depth-1, "and")
+	}
+
+	return schema.ListNestedAttribute{
+		Description: description,
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: attributes,
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			listvalidator.ConflictsWith(
+				path.MatchRelative().AtParent().AtName(conflictsWith),
+			),
+		},
+	}
+}
+
+// validateFrameworks validates the definition of each framework against the
+// API. Each framework is validated separately so that validation errors are
+// reported on the offending frameworks[n].definition attribute.
+func (r *ApplicationSecurityRuleResource) validateFrameworks(ctx context.Context, diagnostics *diag.Diagnostics, frameworks []models.FrameworkModel) {
+	for idx, framework := range frameworks {
+		// Definitions that are not known yet are validated during apply
+		if framework.Name.IsUnknown() || framework.Definition.IsNull() || framework.Definition.IsUnknown() {
+			continue
+		}
+
+		definitionPath := path.Root("frameworks").AtListIndex(idx).AtName("definition")
+
+		response, err := r.client.Validate(ctx, []appsec.ValidateRequest{
+			{
+				Framework:  framework.Name.ValueString(),
+				Definition: framework.Definition.ValueString(),
+			},
+		})
+		if err != nil {
+			diagnostics.AddAttributeError(
+				definitionPath,
+				"Error Validating Application Security Rule",
+				err.Error(),
+			)
+			continue
+		}
+
+		if response.IsValid == nil || !*response.IsValid {
+			detail := fmt.Sprintf("The %s framework definition failed API validation.", framework.Name.ValueString())
+			if len(response.Errors) > 0 {
+				detail += "\n\n" + strings.Join(response.Errors, "\n")
+			}
+
+			diagnostics.AddAttributeError(
+				definitionPath,
+				"Invalid Framework Definition",
+				detail,
+			)
+		}
+	}
+}
+
+// planStateDefinitions sets the planned definition of each framework to
+// its definition in state if both are semantically equal.
+func (r *ApplicationSecurityRuleResource) planStateDefinitions(ctx context.Context, resp *resource.ModifyPlanResponse, plan *models.ApplicationSecurityRuleModel, state models.ApplicationSecurityRuleModel) {
+	stateDefinitions := map[string]customtypes.YAMLStringValue{}
+	for _, framework := range state.Frameworks {
+		stateDefinitions[strings.ToUpper(framework.Name.ValueString())] = framework.Definition
+	}
+
+	for idx, framework := range plan.Frameworks {
+		if framework.Name.IsUnknown() || framework.Definition.IsNull() || framework.Definition.IsUnknown() {
+			continue
+		}
+
+		stateDefinition, ok := stateDefinitions[strings.ToUpper(framework.Name.ValueString())]
+		if !ok || stateDefinition.IsNull() || stateDefinition.IsUnknown() || stateDefinition.Equal(framework.Definition) {
+			continue
+		}
+
+		equal, diags := framework.Definition.StringSemanticEquals(ctx, stateDefinition)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !equal {
+			continue
+		}
+
+		plan.Frameworks[idx].Definition = stateDefinition
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("frameworks").AtListIndex(idx).AtName("definition"), stateDefinition)...)
+	}
+}
+
+// frameworkDefinitionsEqual returns true if both frameworks have the same
+// name and definition.
+func frameworkDefinitionsEqual(a, b models.FrameworkModel) bool {
+	return a.Name.Equal(b.Name) && a.Definition.Equal(b.Definition)
+}
+
+// ImportState imports an existing application security rule into the
+// Terraform state using its ID.
+//
+// The API only returns YAML definitions, so definition_block cannot be
+// reconstructed on import. A rule configured with definition_block is
+// planned for an update after import, which only stores the block in
+// state, as its definition is kept if it is equivalent to the imported one.
+func (r *ApplicationSecurityRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package application_security
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/customtypes"
+	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/application_security"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testObjectValue returns an object of the schema type with the given
+// attribute values and every other attribute set to null.
+func testObjectValue(t *testing.T, ctx context.Context, s schema.Schema, values map[string]attr.Value) tftypes.Value {
+	t.Helper()
+
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+	nullValues := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		nullValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, nullValues)}
+	for name, value := range values {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("unexpected error setting %s: %v", name, diags)
+		}
+	}
+
+	return state.Raw
+}
+
+// testFrameworks returns a frameworks list value with a framework for each
+// name and definition pair.
+func testFrameworks(t *testing.T, s schema.Schema, frameworks ...[2]string) types.List {
+	t.Helper()
+
+	elementType := s.Attributes["frameworks"].GetType().(types.ListType).ElemType.(types.ObjectType)
+
+	elements := []attr.Value{}
+	for _, framework := range frameworks {
+		elements = append(elements, types.ObjectValueMust(elementType.AttrTypes, map[string]attr.Value{
+			"name":                    types.StringValue(framework[0]),
+			"definition":              customtypes.NewYAMLStringValue(framework[1]),
+			"definition_block":        types.ObjectNull(models.FrameworkDefinitionAttrTypes()),
+			"definition_link":         types.StringValue(""),
+			"remediation_description": types.StringValue(""),
+		}))
+	}
+
+	return types.ListValueMust(elementType, elements)
+}
+
+func TestApplicationSecurityRuleResourcePlanStateDefinitions(t *testing.T) {
+	t.Parallel()
+
+	importedDefinition := "scope:\n  provider: aws\ndefinition:\n  cond_type: attribute\n  attribute: acl\n  value: private\n"
+
+	testCases := map[string]struct {
+		planned  string
+		expected string
+	}{
+		"formatting-differs": {
+			planned:  "definition: {cond_type: attribute, attribute: acl, value: \"private\"}\nscope: {provider: aws}\n",
+			expected: importedDefinition,
+		},
+		"injected-metadata-differs": {
+			planned:  "metadata:\n  name: rule\n" + importedDefinition,
+			expected: importedDefinition,
+		},
+		"definition-changed": {
+			planned:  "scope: {provider: aws}\ndefinition: {cond_type: attribute, attribute: acl, value: public}\n",
+			expected: "scope: {provider: aws}\ndefinition: {cond_type: attribute, attribute: acl, value: public}\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &ApplicationSecurityRuleResource{}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			if schemaResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected schema error: %v", schemaResp.Diagnostics)
+			}
+			s := schemaResp.Schema
+
+			state := tfsdk.State{
+				Schema: s,
+				Raw: testObjectValue(t, ctx, s, map[string]attr.Value{
+					"frameworks": testFrameworks(t, s, [2]string{"TERRAFORM", importedDefinition}),
+				}),
+			}
+			plan := tfsdk.Plan{
+				Schema: s,
+				Raw: testObjectValue(t, ctx, s, map[string]attr.Value{
+					"frameworks": testFrameworks(t, s, [2]string{"TERRAFORM", testCase.planned}),
+				}),
+			}
+
+			var planModel, stateModel models.ApplicationSecurityRuleModel
+			if diags := plan.Get(ctx, &planModel); diags.HasError() {
+				t.Fatalf("unexpected error reading plan: %v", diags)
+			}
+			if diags := state.Get(ctx, &stateModel); diags.HasError() {
+				t.Fatalf("unexpected error reading state: %v", diags)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.planStateDefinitions(ctx, resp, &planModel, stateModel)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var definition customtypes.YAMLStringValue
+			resp.Plan.GetAttribute(ctx, path.Root("frameworks").AtListIndex(0).AtName("definition"), &definition)
+
+			if definition.ValueString() != testCase.expected {
+				t.Errorf("expected planned definition:\n%s\ngot:\n%s", testCase.expected, definition.ValueString())
+			}
+
+			if planModel.Frameworks[0].Definition.ValueString() != testCase.expected {
+				t.Errorf("expected plan model definition:\n%s\ngot:\n%s", testCase.expected, planModel.Frameworks[0].Definition.ValueString())
+			}
+		})
+	}
+}
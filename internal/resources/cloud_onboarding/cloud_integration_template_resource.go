@@ -5,34 +5,106 @@ package cloud_onboarding
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
 	"github.com/mdboynton/cortex-cloud-go/enums"
 
 	providerModels "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/provider"
 	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/cloud_onboarding"
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/planmodifiers"
 	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/util"
 	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/validators"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource               = &CloudIntegrationTemplateResource{}
-	_ resource.ResourceWithModifyPlan = &CloudIntegrationTemplateResource{}
+	_ resource.Resource                   = &CloudIntegrationTemplateResource{}
+	_ resource.ResourceWithModifyPlan     = &CloudIntegrationTemplateResource{}
+	_ resource.ResourceWithImportState    = &CloudIntegrationTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &CloudIntegrationTemplateResource{}
 )
 
+// importIdInstanceNamePrefix is the prefix of import IDs that identify the
+// integration by instance name rather than tracking GUID.
+const importIdInstanceNamePrefix = "instance_name:"
+
+const (
+	defaultDeleteTimeout = 10 * time.Minute
+	deletePollInterval   = 10 * time.Second
+
+	defaultWaitForStatusTimeout = 30 * time.Minute
+	waitForStatusPollInterval   = 15 * time.Second
+
+	maxDeploymentTemplateSize = 1 << 20
+)
+
+// deploymentTemplateMediaTypes maps each cloud provider to the media types
+// its deployment template may be served with. Any other media type, such as
+// an HTML error or login page, is rejected.
+var deploymentTemplateMediaTypes = map[string][]string{
+	enums.CloudProviderAzure.String(): {"application/json", "text/plain", "application/octet-stream"},
+	enums.CloudProviderGCP.String():   {"text/plain", "application/octet-stream"},
+}
+
+var (
+	awsOrganizationIdRegex       = regexp.MustCompile(`^o-[a-z0-9]{10,32}$`)
+	awsOrganizationalUnitIdRegex = regexp.MustCompile(`^ou-[a-z0-9]{4,32}-[a-z0-9]{8,32}$`)
+	gcpNumericIdRegex            = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// templateInputExpressions match the attributes that are sent to the API
+// to generate the deployment template. The generated values are only
+// replanned when one of these attributes changes.
+var templateInputExpressions = []path.Expression{
+	path.MatchRoot("account_details"),
+	path.MatchRoot("additional_capabilities"),
+	path.MatchRoot("cloud_provider"),
+	path.MatchRoot("collection_configuration"),
+	path.MatchRoot("custom_resources_tags"),
+	path.MatchRoot("instance_name"),
+	path.MatchRoot("outpost_id"),
+	path.MatchRoot("scan_mode"),
+	path.MatchRoot("scope"),
+	path.MatchRoot("scope_modifications"),
+}
+
+// undeletableIntegrationStatuses contains the statuses in which the API
+// refuses to delete an integration, i.e. before its template has been
+// deployed and the integration has connected to the cloud environment.
+// Integrations in these statuses are only removed from the Terraform state.
+var undeletableIntegrationStatuses = []string{
+	models.IntegrationStatusPending,
+}
+
 // NewCloudIntegrationTemplateResource is a helper function to simplify the provider implementation.
 func NewCloudIntegrationTemplateResource() resource.Resource {
 	return &CloudIntegrationTemplateResource{}
@@ -40,7 +112,9 @@ func NewCloudIntegrationTemplateResource() resource.Resource {
 
 // CloudIntegrationTemplateResource is the resource implementation.
 type CloudIntegrationTemplateResource struct {
-	client *cloudonboarding.Client
+	client      *cloudonboarding.Client
+	httpClient  *http.Client
+	defaultTags map[string]string
 }
 
 // Metadata returns the resource type name.
@@ -53,20 +127,52 @@ func (r *CloudIntegrationTemplateResource) Schema(ctx context.Context, req resou
 	resp.Schema = schema.Schema{
 		Description: "TODO",
 		Attributes: map[string]schema.Attribute{
-			// TODO: currently can only be specified for Azure integrations
 			"account_details": schema.SingleNestedAttribute{
-				Description: "TODO",
-				Optional:    true,
-				Computed:    true,
+				Description: "Details of the cloud organization to onboard. " +
+					"Required if `scope` is `ORGANIZATION`, and cannot be " +
+					"configured otherwise.",
+				Optional: true,
+				Computed: true,
 				Attributes: map[string]schema.Attribute{
 					"organization_id": schema.StringAttribute{
-						// TODO: validation
-						Description: "TODO",
+						Description: "ID of the organization to onboard. " +
+							"For `AWS`, the AWS Organizations ID (e.g. " +
+							"`o-a1b2c3d4e5`). For `GCP`, the numeric " +
+							"organization ID. For `AZURE`, the ID of the " +
+							"tenant or management group.",
+						Required: true,
+					},
+					"organizational_unit_ids": schema.SetAttribute{
+						Description: "IDs of the AWS organizational units " +
+							"to onboard (e.g. `ou-ab12-cd34ef56`). If not " +
+							"configured, the entire organization is " +
+							"onboarded. Can only be configured if " +
+							"`cloud_provider` is `AWS`.",
 						Optional:    true,
-						Computed:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.RegexMatches(awsOrganizationalUnitIdRegex, "must be a valid AWS organizational unit ID"),
+							),
+						},
+					},
+					"folder_ids": schema.SetAttribute{
+						Description: "Numeric IDs of the GCP folders to " +
+							"onboard. If not configured, the entire " +
+							"organization is onboarded. Can only be " +
+							"configured if `cloud_provider` is `GCP`.",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.RegexMatches(gcpNumericIdRegex, "must be a numeric GCP folder ID"),
+							),
+						},
 					},
 				},
-				Default: objectdefault.StaticValue(types.ObjectNull(map[string]attr.Type{"organization_id": types.StringType})),
+				Default: objectdefault.StaticValue(types.ObjectNull(models.AccountDetailsAttrTypes)),
 			},
 			"additional_capabilities": schema.SingleNestedAttribute{
 				Description: "Define which additional security capabilities " +
@@ -115,37 +221,57 @@ func (r *CloudIntegrationTemplateResource) Schema(ctx context.Context, req resou
 									),
 								},
 							},
-							//"last_days": schema.Int32Attribute{
-							//	Description: "Number of days within which " +
-							//		"the tags on a registry image must have " +
-							//		"been created or updated for the image " +
-							//		"to be scanned. Minimum value is 0 and " +
-							//		"maximum value is 90. Cannot be " +
-							//		"configured if `type` is not set to " +
-							//		"`TAGS_MODIFIED_DAYS`.",
-							//	Optional: true,
-							//	Computed: true,
-							//	Validators: []validator.Int32{
-							//		int32validator.Between(0, 90),
-							//		int32validator.AlsoRequires(path.MatchRelative().AtParent().AtName("type")),
-							//	},
-							//	PlanModifiers: []planmodifier.Int32{
-							//		planmodifiers.NullIfAlsoSetInt32(
-							//			[]string{
-							//				util.CloudIntegrationRegistryScanningTypeEnumAll,
-							//				util.CloudIntegrationRegistryScanningTypeEnumLatestTag,
-							//			},
-							//		),
-							//	},
-							//	//Default: int32default.StaticInt32(90),
-							//},
+							"last_days": schema.Int32Attribute{
+								Description: "Number of days within which " +
+									"the tags on a registry image must have " +
+									"been created or updated for the image " +
+									"to be scanned. Minimum value is 0 and " +
+									"maximum value is 90. Cannot be " +
+									"configured if `type` is not set to " +
+									"`TAGS_MODIFIED_DAYS`.",
+								Optional: true,
+								Computed: true,
+								Validators: []validator.Int32{
+									int32validator.Between(0, 90),
+									int32validator.AlsoRequires(path.MatchRelative().AtParent().AtName("type")),
+									validators.ConflictsWithOnStringValues(
+										[]string{
+											enums.RegistryScanningTypeAll.String(),
+											enums.RegistryScanningTypeLatestTag.String(),
+										},
+										path.MatchRelative().AtParent().AtName("type"),
+									),
+								},
+								PlanModifiers: []planmodifier.Int32{
+									planmodifiers.NullIfAlsoSetInt32(
+										[]string{
+											enums.RegistryScanningTypeAll.String(),
+											enums.RegistryScanningTypeLatestTag.String(),
+										},
+										path.MatchRelative().AtParent().AtName("type"),
+									),
+								},
+							},
+						},
+					},
+					"serverless_scanning": schema.BoolAttribute{
+						Description: "Whether to enable serverless scanning, " +
+							"which scans serverless functions for " +
+							"vulnerabilities, secrets and misconfigurations. " +
+							"Only supported if `cloud_provider` is `AWS` or " +
+							"`AZURE`.",
+						Optional: true,
+						Computed: true,
+						Validators: []validator.Bool{
+							validators.EnabledOnlyOnStringValues(
+								[]string{
+									enums.CloudProviderAWS.String(),
+									enums.CloudProviderAzure.String(),
+								},
+								path.MatchRoot("cloud_provider"),
+							),
 						},
 					},
-					//"serverless_scanning": schema.BoolAttribute{
-					//	Description: "TODO",
-					//	Optional:    true,
-					//	Computed:    true,
-					//},
 					"xsiam_analytics": schema.BoolAttribute{
 						Description: "Whether to enable XSIAM analytics to " +
 							"analyze your endpoint data to develop a " +
@@ -213,13 +339,20 @@ func (r *CloudIntegrationTemplateResource) Schema(ctx context.Context, req resou
 				),
 			},
 			"custom_resources_tags": schema.SetNestedAttribute{
-				// TODO: prevent duplicate tag keys
 				Description: "Custom tags that will be applied to any new " +
 					"resource created by Cortex in the cloud environment. " +
 					"By default, the `managed_by` tag will always be " +
-					"applied with the value `paloaltonetworks`.",
+					"applied with the value `paloaltonetworks`. Tag keys " +
+					"must be unique and satisfy the tag naming rules of " +
+					"the cloud provider.",
 				Optional: true,
 				Computed: true,
+				Validators: []validator.Set{
+					validators.ValidateResourceTags(
+						[]string{models.ManagedByTagKey},
+						path.MatchRoot("cloud_provider"),
+					),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"key": schema.StringAttribute{
@@ -235,6 +368,26 @@ func (r *CloudIntegrationTemplateResource) Schema(ctx context.Context, req resou
 					},
 				},
 			},
+			"tags_all": schema.SetNestedAttribute{
+				Description: "All custom tags that will be applied to " +
+					"resources created by Cortex in the cloud environment, " +
+					"including the provider `default_tags`. Tags configured " +
+					"in `custom_resources_tags` override default tags with " +
+					"the same key.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The key of the tag.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The value of the tag.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"instance_name": schema.StringAttribute{
 				// TODO: validation
 				Description: "Name of the integration instance. If left " +
@@ -247,13 +400,11 @@ func (r *CloudIntegrationTemplateResource) Schema(ctx context.Context, req resou
 				Default: stringdefault.StaticString(""),
 			},
 			"scan_mode": schema.StringAttribute{
-				// TODO: add description of outpost
-				// TODO: verify that an outpost id is specified if set to MANAGED
-				// TODO: find out how to get the default outpost id
 				Description: "Define where the scanning for the cloud " +
 					"environment will occur. Must be either `MANAGED` or " +
 					"`OUTPOST`. If set to `MANAGED`, scanning will be done " +
-					"in the Cortex Cloud tenant's environment. If set to " +
+					"in the Cortex Cloud tenant's environment and " +
+					"`outpost_id` must be configured. If set to " +
 					"`OUTPOST`, scanning will be done on the cloud " +
 					"infrastructure owned and managed by you." +
 					"\n\nNOTE: Scanning with an outpost may require " +
@@ -264,6 +415,12 @@ func (r *CloudIntegrationTemplateResource) Schema(ctx context.Context, req resou
 					stringvalidator.OneOf(
 						enums.AllScanModes()...,
 					),
+					validators.AlsoRequiresOnStringValues(
+						[]string{
+							enums.ScanModeManaged.String(),
+						},
+						path.MatchRoot("outpost_id"),
+					),
 				},
 			},
 			"scope": schema.StringAttribute{
@@ -281,165 +438,175 @@ func (r *CloudIntegrationTemplateResource) Schema(ctx context.Context, req resou
 				},
 			},
 			"scope_modifications": schema.SingleNestedAttribute{
-				Description: "Define the scope of scans by including/excluding " +
-					"accounts or regions.",
+				Description: "Define the scope of scans by including or " +
+					"excluding accounts, projects, subscriptions or regions.",
 				Optional: true,
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
 					"accounts": schema.SingleNestedAttribute{
-						Description: "Configuration for account-level scope " +
-							"modifications for AWS integrations. Cannot be " +
-							"configured if `cloud_type` is not set to `AWS`.",
+						Description: "Include or exclude member accounts of an AWS " +
+							"organization. Can only be configured if " +
+							"`cloud_provider` is `AWS` and `scope` is " +
+							"`ORGANIZATION`.",
 						Optional: true,
-						Computed: true,
 						Attributes: map[string]schema.Attribute{
 							"enabled": schema.BoolAttribute{
-								Description: "TODO",
-								Optional:    true,
-								Computed:    true,
+								Description: "Whether to apply this scope " +
+									"modification.",
+								Required: true,
 							},
 							"type": schema.StringAttribute{
-								// TODO: validation ("INCLUDE", "EXCLUDE")
-								Description: "TODO",
-								Optional:    true,
-								Computed:    true,
+								Description: "Whether the listed " +
+									"account IDs are the only ones scanned " +
+									"(`INCLUDE`) or are excluded from " +
+									"scanning (`EXCLUDE`). Required if " +
+									"`enabled` is `true`.",
+								Optional: true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("INCLUDE", "EXCLUDE"),
+								},
 							},
 							"account_ids": schema.SetAttribute{
-								Description: "TODO",
+								Description: "The account IDs to include or " +
+									"exclude. Required if `enabled` is `true`.",
 								Optional:    true,
-								Computed:    true,
 								ElementType: types.StringType,
+								Validators: []validator.Set{
+									setvalidator.SizeAtLeast(1),
+								},
 							},
 						},
-						Default: objectdefault.StaticValue(
-							types.ObjectNull(
-								map[string]attr.Type{
-									"enabled": types.BoolType,
-									"type":    types.StringType,
-									"account_ids": types.SetType{
-										ElemType: types.StringType,
-									},
-								},
-							),
-						),
 					},
 					"projects": schema.SingleNestedAttribute{
-						Description: "TODO",
-						Optional:    true,
-						Computed:    true,
+						Description: "Include or exclude projects of a GCP " +
+							"organization. Can only be configured if " +
+							"`cloud_provider` is `GCP` and `scope` is " +
+							"`ORGANIZATION`.",
+						Optional: true,
 						Attributes: map[string]schema.Attribute{
 							"enabled": schema.BoolAttribute{
-								Description: "TODO",
-								Optional:    true,
-								Computed:    true,
+								Description: "Whether to apply this scope " +
+									"modification.",
+								Required: true,
 							},
 							"type": schema.StringAttribute{
-								// TODO: validation ("INCLUDE", "EXCLUDE")
-								Description: "TODO",
-								Optional:    true,
-								Computed:    true,
+								Description: "Whether the listed " +
+									"project IDs are the only ones scanned " +
+									"(`INCLUDE`) or are excluded from " +
+									"scanning (`EXCLUDE`). Required if " +
+									"`enabled` is `true`.",
+								Optional: true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("INCLUDE", "EXCLUDE"),
+								},
 							},
 							"project_ids": schema.SetAttribute{
-								Description: "TODO",
+								Description: "The project IDs to include or " +
+									"exclude. Required if `enabled` is `true`.",
 								Optional:    true,
-								Computed:    true,
 								ElementType: types.StringType,
+								Validators: []validator.Set{
+									setvalidator.SizeAtLeast(1),
+								},
 							},
 						},
-						Default: objectdefault.StaticValue(
-							types.ObjectNull(
-								map[string]attr.Type{
-									"enabled": types.BoolType,
-									"type":    types.StringType,
-									"project_ids": types.SetType{
-										ElemType: types.StringType,
-									},
-								},
-							),
-						),
 					},
 					"subscriptions": schema.SingleNestedAttribute{
-						Description: "TODO",
-						Optional:    true,
-						Computed:    true,
+						Description: "Include or exclude subscriptions of an Azure " +
+							"tenant. Can only be configured if " +
+							"`cloud_provider` is `AZURE` and `scope` is " +
+							"`ORGANIZATION`.",
+						Optional: true,
 						Attributes: map[string]schema.Attribute{
 							"enabled": schema.BoolAttribute{
-								Description: "TODO",
-								Optional:    true,
-								Computed:    true,
+								Description: "Whether to apply this scope " +
+									"modification.",
+								Required: true,
 							},
 							"type": schema.StringAttribute{
-								// TODO: validation ("INCLUDE", "EXCLUDE")
-								Description: "TODO",
-								Optional:    true,
-								Computed:    true,
+								Description: "Whether the listed " +
+									"subscription IDs are the only ones scanned " +
+									"(`INCLUDE`) or are excluded from " +
+									"scanning (`EXCLUDE`). Required if " +
+									"`enabled` is `true`.",
+								Optional: true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("INCLUDE", "EXCLUDE"),
+								},
 							},
 							"subscription_ids": schema.SetAttribute{
-								Description: "TODO",
+								Description: "The subscription IDs to include or " +
+									"exclude. Required if `enabled` is `true`.",
 								Optional:    true,
-								Computed:    true,
 								ElementType: types.StringType,
+								Validators: []validator.Set{
+									setvalidator.SizeAtLeast(1),
+								},
 							},
 						},
-						Default: objectdefault.StaticValue(
-							types.ObjectNull(
-								map[string]attr.Type{
-									"enabled": types.BoolType,
-									"type":    types.StringType,
-									"subscription_ids": types.SetType{
-										ElemType: types.StringType,
-									},
-								},
-							),
-						),
 					},
 					"regions": schema.SingleNestedAttribute{
-						Description: "TODO",
+						Description: "Include or exclude cloud provider regions.",
 						Optional:    true,
-						Computed:    true,
 						Attributes: map[string]schema.Attribute{
-							// TODO: do we need an enabled attribute or is it
-							// not needed since it's optional?
 							"enabled": schema.BoolAttribute{
-								Description: "TODO",
-								Required:    true,
-								//Optional:    true,
-								//Computed:    true,
+								Description: "Whether to apply this scope " +
+									"modification.",
+								Required: true,
 							},
 							"type": schema.StringAttribute{
-								// TODO: validation ("INCLUDE", "EXCLUDE")
-								Description: "TODO",
-								//Required:    true,
+								Description: "Whether the listed " +
+									"regions are the only ones scanned " +
+									"(`INCLUDE`) or are excluded from " +
+									"scanning (`EXCLUDE`). Required if " +
+									"`enabled` is `true`.",
 								Optional: true,
-								//Computed:    true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("INCLUDE", "EXCLUDE"),
+								},
 							},
 							"regions": schema.SetAttribute{
-								Description: "TODO",
+								Description: "The regions to include or " +
+									"exclude. Required if `enabled` is `true`.",
 								Optional:    true,
-								//Computed:    true,
 								ElementType: types.StringType,
+								Validators: []validator.Set{
+									setvalidator.SizeAtLeast(1),
+								},
 							},
 						},
-						Default: objectdefault.StaticValue(
-							types.ObjectNull(
-								map[string]attr.Type{
-									"enabled": types.BoolType,
-									"type":    types.StringType,
-									"regions": types.SetType{
-										ElemType: types.StringType,
-									},
-								},
-							),
-						),
 					},
 				},
+				Default: objectdefault.StaticValue(
+					types.ObjectNull(models.ScopeModificationsAttrTypes),
+				),
 			},
 			"status": schema.StringAttribute{
-				Description: "Status of the integration.",
-				Computed:    true,
-				Default:     stringdefault.StaticString("PENDING"),
+				Description: "Status of the integration. The integration " +
+					"remains `PENDING` until the template is deployed in the " +
+					"cloud provider, after which it is one of `CONNECTED`, " +
+					"`WARNING`, `ERROR` or `DISABLED`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_name": schema.StringAttribute{
+				Description: "Name of the cloud account connected to the " +
+					"integration. Populated once the template has been deployed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_time": schema.Int64Attribute{
+				Description: "Time the integration was created, in " +
+					"milliseconds since the Unix epoch.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			// TODO: Planmodifier to use state if config values are unchanged
 			"tracking_guid": schema.StringAttribute{
 				Description: "TODO (be sure to mention that this is the instance_id)",
 				Computed:    true,
@@ -447,40 +614,122 @@ func (r *CloudIntegrationTemplateResource) Schema(ctx context.Context, req resou
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			// TODO: make this a configurable attribute
-			// (this is set to null in the platform if not configured)
+			"wait_for_status": schema.SingleNestedAttribute{
+				Description: "If configured, the provider waits after the " +
+					"integration is created or updated until Cortex Cloud " +
+					"reports the target status, e.g. once the generated " +
+					"template has been deployed in the cloud provider. The " +
+					"operation fails if the integration reports an `ERROR` " +
+					"status or the `create` or `update` timeout configured in " +
+					"the `timeouts` block is reached.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"target_status": schema.StringAttribute{
+						Description: "Status to wait for. Must be one of " +
+							"`CONNECTED` or `WARNING`. Defaults to `CONNECTED`.",
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(models.IntegrationStatusConnected),
+						Validators: []validator.String{
+							stringvalidator.OneOf(models.IntegrationStatusConnected, models.IntegrationStatusWarning),
+						},
+					},
+				},
+			},
 			"outpost_id": schema.StringAttribute{
-				Description: "TODO",
-				Computed:    true,
+				Description: "ID of the outpost used for scanning the " +
+					"cloud environment. Required if `scan_mode` is " +
+					"`MANAGED`. Available outposts can be listed with the " +
+					"`cortexcloud_outposts` data source.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			// TODO: Planmodifier to use state if config values are unchanged
 			"automated_deployment_link": schema.StringAttribute{
 				Description: "TODO",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					planmodifiers.UseStateIfUnchangedString(templateInputExpressions...),
 				},
 			},
-			// TODO: Planmodifier to use state if config values are unchanged
 			"manual_deployment_link": schema.StringAttribute{
 				Description: "TODO",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					planmodifiers.UseStateIfUnchangedString(templateInputExpressions...),
 				},
 			},
-			// TODO: Planmodifier to use state if config values are unchanged
 			"cloud_formation_template_url": schema.StringAttribute{
 				Description: "TODO",
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					planmodifiers.UseStateIfUnchangedString(templateInputExpressions...),
+				},
+			},
+			"cloud_formation_stack_name": schema.StringAttribute{
+				Description: "The stack name of the generated CloudFormation " +
+					"quick-create link. Only populated for `AWS` " +
+					"integrations, and can be passed to the `name` " +
+					"argument of `aws_cloudformation_stack`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.UseStateIfUnchangedString(templateInputExpressions...),
+				},
+			},
+			"cloud_formation_stack_parameters": schema.MapAttribute{
+				Description: "The parameters of the generated CloudFormation " +
+					"stack, keyed by parameter name. Only populated for " +
+					"`AWS` integrations, and can be passed to the " +
+					"`parameters` argument of `aws_cloudformation_stack`.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					planmodifiers.UseStateIfUnchangedMap(templateInputExpressions...),
+				},
+			},
+			"arm_template": schema.StringAttribute{
+				Description: "The JSON content of the generated ARM template. " +
+					"Only populated for `AZURE` integrations, and can be " +
+					"passed to the `template_content` argument of " +
+					"`azurerm_resource_group_template_deployment`. " +
+					"Null if the template could not be downloaded, in which " +
+					"case it can be retrieved from `manual_deployment_link`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.UseStateIfUnchangedString(templateInputExpressions...),
 				},
 			},
+			"terraform_module": schema.StringAttribute{
+				Description: "The content of the generated Terraform module. " +
+					"Only populated for `GCP` integrations, and can be " +
+					"written to a local module with the `local_file` " +
+					"resource. " +
+					"Null if the template could not be downloaded, in which " +
+					"case it can be retrieved from `manual_deployment_link`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.UseStateIfUnchangedString(templateInputExpressions...),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+				CreateDescription: "Maximum time to wait for the status " +
+					"configured in `wait_for_status` after creating the " +
+					"integration. Defaults to `30m`.",
+				UpdateDescription: "Maximum time to wait for the status " +
+					"configured in `wait_for_status` after updating the " +
+					"integration. Defaults to `30m`.",
+				DeleteDescription: "Maximum time to wait for the " +
+					"integration to be removed after deleting it. Defaults " +
+					"to `10m`.",
+			}),
 		},
 	}
 }
@@ -500,20 +749,307 @@ func (r *CloudIntegrationTemplateResource) Configure(ctx context.Context, req re
 	}
 
 	r.client = client.CloudOnboarding
+	r.httpClient = client.HTTPClient
+	r.defaultTags = client.DefaultTags
+}
+
+// ValidateConfig validates the account details and scope modifications
+// against the configured cloud provider and scope.
+func (r *CloudIntegrationTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config models.CloudIntegrationTemplateModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateAccountDetails(ctx, &resp.Diagnostics, config)
+
+	if config.ScopeModifications.IsNull() || config.ScopeModifications.IsUnknown() {
+		return
+	}
+
+	var scopeModifications models.ScopeModificationsModel
+	resp.Diagnostics.Append(config.ScopeModifications.As(ctx, &scopeModifications, basetypes.ObjectAsOptions{
+		UnhandledUnknownAsEmpty: true,
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	basePath := path.Root("scope_modifications")
+	organizationScope := []string{enums.ScopeOrganization.String()}
+
+	if v := scopeModifications.Accounts; v != nil {
+		validateScopeModification(&resp.Diagnostics, basePath.AtName("accounts"), config, enums.CloudProviderAWS.String(), organizationScope, v.Enabled, v.Type, v.AccountIds, "account_ids")
+	}
+	if v := scopeModifications.Projects; v != nil {
+		validateScopeModification(&resp.Diagnostics, basePath.AtName("projects"), config, enums.CloudProviderGCP.String(), organizationScope, v.Enabled, v.Type, v.ProjectIds, "project_ids")
+	}
+	if v := scopeModifications.Subscriptions; v != nil {
+		validateScopeModification(&resp.Diagnostics, basePath.AtName("subscriptions"), config, enums.CloudProviderAzure.String(), organizationScope, v.Enabled, v.Type, v.SubscriptionIds, "subscription_ids")
+	}
+	if v := scopeModifications.Regions; v != nil {
+		validateScopeModification(&resp.Diagnostics, basePath.AtName("regions"), config, "", nil, v.Enabled, v.Type, v.Regions, "regions")
+	}
+}
+
+// validateAccountDetails adds an error if account_details is not configured
+// consistently with the scope and cloud provider.
+func validateAccountDetails(ctx context.Context, diagnostics *diag.Diagnostics, config models.CloudIntegrationTemplateModel) {
+	p := path.Root("account_details")
+	if config.Scope.IsUnknown() || config.CloudProvider.IsUnknown() || config.AccountDetails.IsUnknown() {
+		return
+	}
+
+	isOrganizationScope := config.Scope.ValueString() == enums.ScopeOrganization.String()
+	if config.AccountDetails.IsNull() {
+		if isOrganizationScope {
+			diagnostics.AddAttributeError(
+				p,
+				"Missing Account Details",
+				fmt.Sprintf("Attribute %q must be configured if scope is %q.", p, enums.ScopeOrganization.String()),
+			)
+		}
+		return
+	}
+
+	if !isOrganizationScope {
+		diagnostics.AddAttributeError(
+			p,
+			"Invalid Account Details",
+			fmt.Sprintf("Attribute %q can only be configured if scope is %q, got %q.", p, enums.ScopeOrganization.String(), config.Scope.ValueString()),
+		)
+		return
+	}
+
+	var accountDetails models.AccountDetailsModel
+	diagnostics.Append(config.AccountDetails.As(ctx, &accountDetails, basetypes.ObjectAsOptions{
+		UnhandledUnknownAsEmpty: true,
+	})...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	cloudProvider := config.CloudProvider.ValueString()
+	if !accountDetails.OrganizationalUnitIds.IsNull() && cloudProvider != enums.CloudProviderAWS.String() {
+		diagnostics.AddAttributeError(
+			p.AtName("organizational_unit_ids"),
+			"Invalid Account Details",
+			fmt.Sprintf("Attribute %q can only be configured if cloud_provider is %q, got %q.", p.AtName("organizational_unit_ids"), enums.CloudProviderAWS.String(), cloudProvider),
+		)
+	}
+
+	if !accountDetails.FolderIds.IsNull() && cloudProvider != enums.CloudProviderGCP.String() {
+		diagnostics.AddAttributeError(
+			p.AtName("folder_ids"),
+			"Invalid Account Details",
+			fmt.Sprintf("Attribute %q can only be configured if cloud_provider is %q, got %q.", p.AtName("folder_ids"), enums.CloudProviderGCP.String(), cloudProvider),
+		)
+	}
+
+	if accountDetails.OrganizationId.IsNull() || accountDetails.OrganizationId.IsUnknown() {
+		return
+	}
+
+	organizationId := accountDetails.OrganizationId.ValueString()
+	switch {
+	case cloudProvider == enums.CloudProviderAWS.String() && !awsOrganizationIdRegex.MatchString(organizationId):
+		diagnostics.AddAttributeError(
+			p.AtName("organization_id"),
+			"Invalid Organization ID",
+			fmt.Sprintf("Value %q is not a valid AWS Organizations ID. Expected a value such as \"o-a1b2c3d4e5\".", organizationId),
+		)
+	case cloudProvider == enums.CloudProviderGCP.String() && !gcpNumericIdRegex.MatchString(organizationId):
+		diagnostics.AddAttributeError(
+			p.AtName("organization_id"),
+			"Invalid Organization ID",
+			fmt.Sprintf("Value %q is not a valid GCP organization ID. Expected a numeric ID.", organizationId),
+		)
+	}
+}
+
+// validateScopeModification adds an error if a scope modification entry is
+// configured for a cloud provider other than cloudProvider or a scope not in
+// scopes, or is enabled without a type and IDs. An empty cloudProvider or
+// nil scopes allows any value.
+func validateScopeModification(diagnostics *diag.Diagnostics, p path.Path, config models.CloudIntegrationTemplateModel, cloudProvider string, scopes []string, enabled types.Bool, modificationType types.String, ids types.Set, idsAttribute string) {
+	if cloudProvider != "" && !config.CloudProvider.IsUnknown() && config.CloudProvider.ValueString() != cloudProvider {
+		diagnostics.AddAttributeError(
+			p,
+			"Invalid Scope Modification",
+			fmt.Sprintf("Attribute %q can only be configured if cloud_provider is %q, got %q.", p, cloudProvider, config.CloudProvider.ValueString()),
+		)
+		return
+	}
+
+	if scopes != nil && !config.Scope.IsUnknown() && !slices.Contains(scopes, config.Scope.ValueString()) {
+		diagnostics.AddAttributeError(
+			p,
+			"Invalid Scope Modification",
+			fmt.Sprintf("Attribute %q can only be configured if scope is one of [%s], got %q.", p, strings.Join(scopes, ", "), config.Scope.ValueString()),
+		)
+		return
+	}
+
+	if !enabled.ValueBool() {
+		return
+	}
+
+	if modificationType.IsNull() {
+		diagnostics.AddAttributeError(
+			p.AtName("type"),
+			"Missing Scope Modification Type",
+			fmt.Sprintf("Attribute %q must be configured if %q is true.", p.AtName("type"), p.AtName("enabled")),
+		)
+	}
+
+	if ids.IsNull() {
+		diagnostics.AddAttributeError(
+			p.AtName(idsAttribute),
+			"Missing Scope Modification Values",
+			fmt.Sprintf("Attribute %q must be configured if %q is true.", p.AtName(idsAttribute), p.AtName("enabled")),
+		)
+	}
 }
 
 func (r *CloudIntegrationTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// If the entire plan is null, the resource is planned for destruction
 	if req.Plan.Raw.IsNull() {
-		resp.Diagnostics.AddWarning(
-			"Resource Destruction Considerations",
-			"Applying this resource destruction will only remove the "+
-				"resource from the Terraform state and will not delete the "+
-				"template due to API limitations. Manually delete the template "+
-				"in the Data Sources section of the Cortex Cloud console to "+
-				"fully destroy this resource.",
+		return
+	}
+
+	// Read Terraform plan data into model
+	var plan models.CloudIntegrationTemplateModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// If custom resource tags are not configured, plan an empty set rather
+	// than an unknown value so that only the provider default tags are
+	// applied
+	var configTags types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("custom_resources_tags"), &configTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configTags.IsNull() {
+		plan.CustomResourcesTags = types.SetValueMust(models.TagObjectType, []attr.Value{})
+	}
+
+	// Verify that the configured outpost exists for the cloud provider
+	r.validateOutpost(ctx, req, resp, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Verify that the provider default tags satisfy the tag rules of the
+	// cloud provider
+	defaultTagsDiags := validators.ValidateTagMap(path.Root("tags_all"), r.defaultTags, []string{models.ManagedByTagKey}, plan.CloudProvider.ValueString())
+	for _, d := range defaultTagsDiags.Errors() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tags_all"),
+			d.Summary(),
+			fmt.Sprintf("Invalid provider default tag: %s", d.Detail()),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Merge provider default tags into planned tags
+	plan.ApplyDefaultTags(ctx, &resp.Diagnostics, r.defaultTags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("custom_resources_tags"), plan.CustomResourcesTags)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), plan.TagsAll)...)
+
+	// The merged tags are applied by the generated template, so a change
+	// to the provider default tags also regenerates it
+	r.planTemplateRegeneration(ctx, req, resp, plan)
+
+	// If the integration will be created or updated and the provider waits
+	// for its status to change, the lifecycle values are not known until
+	// after apply
+	if !plan.WaitForStatus.IsNull() && (req.State.Raw.IsNull() || !req.Plan.Raw.Equal(req.State.Raw)) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("account_name"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("creation_time"), types.Int64Unknown())...)
+	}
+}
+
+// planTemplateRegeneration marks the generated template values as unknown
+// if the merged tags differ from the prior state. This cannot be detected by
+// the attribute plan modifiers, which run before the provider default tags
+// are merged into tags_all.
+func (r *CloudIntegrationTemplateResource) planTemplateRegeneration(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan models.CloudIntegrationTemplateModel) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var stateTags types.Set
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tags_all"), &stateTags)...)
+	if resp.Diagnostics.HasError() || stateTags.Equal(plan.TagsAll) {
+		return
+	}
+
+	for _, attribute := range []string{"automated_deployment_link", "manual_deployment_link", "cloud_formation_template_url", "cloud_formation_stack_name", "arm_template", "terraform_module"} {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), types.StringUnknown())...)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cloud_formation_stack_parameters"), types.MapUnknown(types.StringType))...)
+}
+
+// validateOutpost adds an error if the planned outpost_id does not match an
+// outpost for the planned cloud provider. The API is only queried if the
+// outpost ID has changed.
+func (r *CloudIntegrationTemplateResource) validateOutpost(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan models.CloudIntegrationTemplateModel) {
+	if r.client == nil || plan.OutpostId.IsNull() || plan.OutpostId.IsUnknown() || plan.CloudProvider.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var stateOutpostId types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("outpost_id"), &stateOutpostId)...)
+		if resp.Diagnostics.HasError() || stateOutpostId.Equal(plan.OutpostId) {
+			return
+		}
+	}
+
+	outpostId := plan.OutpostId.ValueString()
+	response, err := r.client.ListOutposts(ctx, models.ToOutpostLookupRequest(outpostId))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Outpost Lookup Error",
+			err.Error(),
 		)
+		return
 	}
+
+	for _, outpost := range response.Reply.Data {
+		if outpost.OutpostId != outpostId {
+			continue
+		}
+
+		if outpost.CloudProvider != plan.CloudProvider.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("outpost_id"),
+				"Invalid Outpost",
+				fmt.Sprintf("Outpost %q is deployed in %s and cannot be used "+
+					"to scan a %s cloud environment.", outpostId, outpost.CloudProvider, plan.CloudProvider.ValueString()),
+			)
+		}
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("outpost_id"),
+		"Invalid Outpost",
+		fmt.Sprintf("No outpost was found with ID %q. Use the "+
+			"cortexcloud_outposts data source to list the available outposts.", outpostId),
+	)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -550,6 +1086,14 @@ func (r *CloudIntegrationTemplateResource) Create(ctx context.Context, req resou
 		return
 	}
 
+	// Download the manual deployment template, if applicable
+	r.refreshDeploymentTemplate(ctx, &resp.Diagnostics, &plan, response)
+
+	// Wait for the integration to reach the target status, if configured
+	r.waitForStatus(ctx, &resp.Diagnostics, &plan, func(t timeouts.Value) (time.Duration, diag.Diagnostics) {
+		return t.Create(ctx, defaultWaitForStatusTimeout)
+	})
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -580,8 +1124,17 @@ func (r *CloudIntegrationTemplateResource) Read(ctx context.Context, req resourc
 		return
 	}
 
+	// Remove resource from state if the integration no longer exists
+	if len(response.Reply.Data) == 0 {
+		tflog.Warn(ctx, "Cloud integration not found, removing from state", map[string]any{
+			"tracking_guid": state.TrackingGuid.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	// Refresh state values
-	state.RefreshConfiguredPropertyValues(ctx, &resp.Diagnostics, response)
+	state.RefreshConfiguredPropertyValues(ctx, &resp.Diagnostics, response, r.defaultTags)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -623,6 +1176,14 @@ func (r *CloudIntegrationTemplateResource) Update(ctx context.Context, req resou
 		return
 	}
 
+	// Download the manual deployment template, if applicable
+	r.refreshDeploymentTemplate(ctx, &resp.Diagnostics, &plan, response)
+
+	// Wait for the integration to reach the target status, if configured
+	r.waitForStatus(ctx, &resp.Diagnostics, &plan, func(t timeouts.Value) (time.Duration, diag.Diagnostics) {
+		return t.Update(ctx, defaultWaitForStatusTimeout)
+	})
+
 	// Set state to updated values
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -638,6 +1199,332 @@ func (r *CloudIntegrationTemplateResource) Delete(ctx context.Context, req resou
 		return
 	}
 
-	// Delete template
-	r.client.DeleteInstances(ctx, []string{state.TrackingGuid.ValueString()})
+	// Delete integration
+	request := state.ToDeleteRequest(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteErr := r.client.DeleteInstances(ctx, request.Data.Ids)
+	if deleteErr != nil {
+		// Check whether the API refused to delete the integration because
+		// of its current status
+		response, err := r.client.ListInstances(ctx, models.ToLookupRequest(models.IntegrationSearchFieldId, state.TrackingGuid.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cloud Integration Template Delete Error",
+				deleteErr.Error(),
+			)
+			return
+		}
+
+		if len(response.Reply.Data) == 0 {
+			return
+		}
+
+		if status := response.Reply.Data[0].Status; slices.Contains(undeletableIntegrationStatuses, status) {
+			resp.Diagnostics.AddWarning(
+				"Cloud Integration Template Not Deleted",
+				fmt.Sprintf("The Cortex Cloud API does not allow deleting "+
+					"integrations with status %s, so the integration has only "+
+					"been removed from the Terraform state. Manually delete the "+
+					"template in the Data Sources section of the Cortex Cloud "+
+					"console to fully destroy this resource.\n\n"+
+					"API error: %s", status, deleteErr.Error()),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Cloud Integration Template Delete Error",
+			deleteErr.Error(),
+		)
+		return
+	}
+
+	// Wait for the integration to be removed
+	timeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.waitForDeletion(ctx, state.TrackingGuid.ValueString(), timeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Cloud Integration Template Delete Error",
+			err.Error(),
+		)
+	}
+}
+
+// waitForDeletion polls the API until the integration with the given
+// tracking GUID is no longer returned, or the timeout elapses.
+func (r *CloudIntegrationTemplateResource) waitForDeletion(ctx context.Context, trackingGuid string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request := models.ToLookupRequest(models.IntegrationSearchFieldId, trackingGuid)
+	for {
+		response, err := r.client.ListInstances(ctx, request)
+		if err != nil {
+			return fmt.Errorf("error checking deletion status of integration %s: %w", trackingGuid, err)
+		}
+
+		if len(response.Reply.Data) == 0 {
+			return nil
+		}
+
+		tflog.Debug(ctx, "Waiting for cloud integration deletion", map[string]any{
+			"tracking_guid": trackingGuid,
+			"status":        response.Reply.Data[0].Status,
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for integration %s to be deleted (last status: %s)", trackingGuid, response.Reply.Data[0].Status)
+		case <-time.After(deletePollInterval):
+		}
+	}
+}
+
+// waitForStatus polls the API until the integration reaches the status
+// configured in wait_for_status, populating the lifecycle values of the
+// model with the last observed values. It does nothing if wait_for_status
+// is not configured.
+//
+// An error diagnostic is added if the integration reports an ERROR status
+// or the timeout returned by getTimeout elapses. The model is still
+// populated in that case so that the integration is saved to state.
+func (r *CloudIntegrationTemplateResource) waitForStatus(ctx context.Context, diagnostics *diag.Diagnostics, m *models.CloudIntegrationTemplateModel, getTimeout func(timeouts.Value) (time.Duration, diag.Diagnostics)) {
+	if m.WaitForStatus.IsNull() || m.WaitForStatus.IsUnknown() {
+		return
+	}
+
+	var waitForStatus models.WaitForStatusModel
+	diagnostics.Append(m.WaitForStatus.As(ctx, &waitForStatus, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := getTimeout(m.Timeouts)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	trackingGuid := m.TrackingGuid.ValueString()
+	targetStatus := waitForStatus.TargetStatus.ValueString()
+	request := models.ToLookupRequest(models.IntegrationSearchFieldId, trackingGuid)
+	for {
+		response, err := r.client.ListInstances(ctx, request)
+		if err != nil {
+			diagnostics.AddError(
+				"Cloud Integration Status Error",
+				fmt.Sprintf("Error retrieving status of integration %s: %s", trackingGuid, err.Error()),
+			)
+			return
+		}
+
+		status := ""
+		if len(response.Reply.Data) > 0 {
+			m.RefreshStatusPropertyValues(response.Reply.Data[0])
+			status = response.Reply.Data[0].Status
+		}
+
+		switch status {
+		case targetStatus:
+			return
+		case models.IntegrationStatusError:
+			diagnostics.AddError(
+				"Cloud Integration Failed",
+				fmt.Sprintf("Integration %s reported status ERROR while waiting "+
+					"for status %s. Review the integration in the Data Sources "+
+					"section of the Cortex Cloud console and verify that the "+
+					"generated template was deployed successfully.", trackingGuid, targetStatus),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Waiting for cloud integration status", map[string]any{
+			"tracking_guid": trackingGuid,
+			"status":        status,
+			"target_status": targetStatus,
+		})
+
+		select {
+		case <-ctx.Done():
+			diagnostics.AddError(
+				"Cloud Integration Status Timeout",
+				fmt.Sprintf("Timed out after %s waiting for integration %s to "+
+					"reach status %s (last status: %s). Deploy the generated "+
+					"template in the cloud provider, or increase the timeout in "+
+					"the timeouts block.", timeout, trackingGuid, targetStatus, status),
+			)
+			return
+		case <-time.After(waitForStatusPollInterval):
+		}
+	}
+}
+
+// ImportState imports an existing integration by tracking GUID, or by
+// instance name if the import ID is prefixed with "instance_name:".
+func (r *CloudIntegrationTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	defer util.PanicHandler(&resp.Diagnostics)
+
+	// Determine the lookup criteria from the import ID
+	searchField, searchValue, searchDescription := models.IntegrationSearchFieldId, req.ID, "tracking GUID"
+	if instanceName, ok := strings.CutPrefix(req.ID, importIdInstanceNamePrefix); ok {
+		searchField, searchValue, searchDescription = models.IntegrationSearchFieldInstanceName, instanceName, "instance name"
+	}
+
+	if searchValue == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID in the format \"<tracking_guid>\" or \"%s<instance_name>\", got %q.", importIdInstanceNamePrefix, req.ID),
+		)
+		return
+	}
+
+	// Retrieve integration details from API
+	response, err := r.client.ListInstances(ctx, models.ToLookupRequest(searchField, searchValue))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cloud Integration Template Import Error",
+			err.Error(),
+		)
+		return
+	}
+
+	switch len(response.Reply.Data) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Cloud Integration Template Not Found",
+			fmt.Sprintf("No cloud integration was found with %s %q.", searchDescription, searchValue),
+		)
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError(
+			"Multiple Cloud Integration Templates Found",
+			fmt.Sprintf("%d cloud integrations were found with %s %q. Import the integration by tracking GUID instead.", len(response.Reply.Data), searchDescription, searchValue),
+		)
+		return
+	}
+
+	// Initialize state with the tracking GUID so that the remaining
+	// attributes are populated as typed null values
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tracking_guid"), response.Reply.Data[0].Id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state models.CloudIntegrationTemplateModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Populate API response values into model
+	state.RefreshConfiguredPropertyValues(ctx, &resp.Diagnostics, response, r.defaultTags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set imported state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// refreshDeploymentTemplate downloads the manual deployment template of
+// Azure and GCP integrations so that it can be deployed within the same
+// configuration. Download failures are reported as warnings, since the
+// integration itself has already been created or updated.
+func (r *CloudIntegrationTemplateResource) refreshDeploymentTemplate(ctx context.Context, diagnostics *diag.Diagnostics, m *models.CloudIntegrationTemplateModel, response cloudonboarding.CreateTemplateOrEditIntegrationInstanceResponse) {
+	content := types.StringNull()
+	if m.DeploymentTemplateUnknown() {
+		content = r.deploymentTemplateContent(ctx, diagnostics, m.CloudProvider.ValueString(), response.Reply.Manual.TF_ARM)
+	}
+
+	m.RefreshDeploymentTemplate(content)
+}
+
+// deploymentTemplateContent returns the content of the deployment template
+// served at link, or null if it could not be downloaded.
+func (r *CloudIntegrationTemplateResource) deploymentTemplateContent(ctx context.Context, diagnostics *diag.Diagnostics, cloudProvider string, link string) types.String {
+	if link == "" {
+		return types.StringNull()
+	}
+
+	content, err := r.downloadDeploymentTemplate(ctx, cloudProvider, link)
+	if err != nil {
+		diagnostics.AddWarning(
+			"Deployment Template Download Error",
+			fmt.Sprintf("Unable to download the deployment template: %s\n\n"+
+				"The template can still be downloaded from manual_deployment_link.", err.Error()),
+		)
+		return types.StringNull()
+	}
+
+	if cloudProvider == enums.CloudProviderAzure.String() && !json.Valid(content) {
+		diagnostics.AddWarning(
+			"Invalid Deployment Template",
+			"The downloaded ARM template is not valid JSON, so arm_template "+
+				"will not be populated. The template can still be downloaded "+
+				"from manual_deployment_link.",
+		)
+		return types.StringNull()
+	}
+
+	if !utf8.Valid(content) {
+		diagnostics.AddWarning(
+			"Invalid Deployment Template",
+			"The downloaded Terraform module is not a text file, so "+
+				"terraform_module will not be populated. The module can "+
+				"still be downloaded from manual_deployment_link.",
+		)
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(content))
+}
+
+// downloadDeploymentTemplate returns the content of the deployment template
+// of cloudProvider served at link.
+func (r *CloudIntegrationTemplateResource) downloadDeploymentTemplate(ctx context.Context, cloudProvider string, link string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", response.Status)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid response content type %q: %w", response.Header.Get("Content-Type"), err)
+	}
+
+	if !slices.Contains(deploymentTemplateMediaTypes[cloudProvider], mediaType) {
+		return nil, fmt.Errorf("unexpected response content type %q", mediaType)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(response.Body, maxDeploymentTemplateSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) > maxDeploymentTemplateSize {
+		return nil, fmt.Errorf("template exceeds the maximum size of %d bytes", maxDeploymentTemplateSize)
+	}
+
+	return content, nil
 }
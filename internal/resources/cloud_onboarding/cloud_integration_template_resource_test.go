@@ -0,0 +1,176 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud_onboarding
+
+import (
+	"context"
+	"testing"
+
+	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/cloud_onboarding"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// nullObjectValue returns an object of the schema type with every
+// attribute set to null.
+func nullObjectValue(ctx context.Context, s schema.Schema) tftypes.Value {
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	return tftypes.NewValue(objectType, values)
+}
+
+// testObjectValue returns an object of the schema type with the given
+// attribute values and every other attribute set to null.
+func testObjectValue(t *testing.T, ctx context.Context, s schema.Schema, values map[string]attr.Value) tftypes.Value {
+	t.Helper()
+
+	state := tfsdk.State{Schema: s, Raw: nullObjectValue(ctx, s)}
+	for name, value := range values {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("unexpected error setting %s: %v", name, diags)
+		}
+	}
+
+	return state.Raw
+}
+
+func testTags(t *testing.T, tags map[string]string) types.Set {
+	t.Helper()
+
+	elements := []attr.Value{}
+	for key, value := range tags {
+		elements = append(elements, types.ObjectValueMust(models.TagObjectType.AttrTypes, map[string]attr.Value{
+			"key":   types.StringValue(key),
+			"value": types.StringValue(value),
+		}))
+	}
+
+	return types.SetValueMust(models.TagObjectType, elements)
+}
+
+// applyStringPlanModifiers runs the plan modifiers of the named String
+// attribute and sets the resulting value in plan.
+func applyStringPlanModifiers(t *testing.T, ctx context.Context, s schema.Schema, name string, config tfsdk.Config, plan *tfsdk.Plan, state tfsdk.State) {
+	t.Helper()
+
+	attributePath := path.Root(name)
+
+	var configValue, planValue, stateValue types.String
+	config.GetAttribute(ctx, attributePath, &configValue)
+	plan.GetAttribute(ctx, attributePath, &planValue)
+	state.GetAttribute(ctx, attributePath, &stateValue)
+
+	for _, modifier := range s.Attributes[name].(schema.StringAttribute).PlanModifiers {
+		req := planmodifier.StringRequest{
+			Path:           attributePath,
+			PathExpression: attributePath.Expression(),
+			Config:         config,
+			ConfigValue:    configValue,
+			Plan:           *plan,
+			PlanValue:      planValue,
+			State:          state,
+			StateValue:     stateValue,
+		}
+		resp := &planmodifier.StringResponse{PlanValue: planValue}
+
+		modifier.PlanModifyString(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error modifying %s: %v", name, resp.Diagnostics)
+		}
+
+		planValue = resp.PlanValue
+	}
+
+	if diags := plan.SetAttribute(ctx, attributePath, planValue); diags.HasError() {
+		t.Fatalf("unexpected error setting %s: %v", name, diags)
+	}
+}
+
+// TestCloudIntegrationTemplateResourcePlanTemplateInputChange verifies that
+// an update that changes a template input and the merged tags replans the
+// generated template values, while the instance ID sent to EditInstance is
+// kept from the prior state.
+func TestCloudIntegrationTemplateResourcePlanTemplateInputChange(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &CloudIntegrationTemplateResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema error: %v", schemaResp.Diagnostics)
+	}
+	s := schemaResp.Schema
+
+	state := tfsdk.State{
+		Schema: s,
+		Raw: testObjectValue(t, ctx, s, map[string]attr.Value{
+			"cloud_provider":         types.StringValue("AZURE"),
+			"instance_name":          types.StringValue("old"),
+			"tracking_guid":          types.StringValue("instance-guid"),
+			"manual_deployment_link": types.StringValue("https://example.com/old"),
+			"tags_all":               testTags(t, map[string]string{"team": "platform"}),
+		}),
+	}
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw: testObjectValue(t, ctx, s, map[string]attr.Value{
+			"cloud_provider":         types.StringValue("AZURE"),
+			"instance_name":          types.StringValue("new"),
+			"tracking_guid":          types.StringUnknown(),
+			"manual_deployment_link": types.StringUnknown(),
+			"tags_all":               testTags(t, map[string]string{"team": "security"}),
+		}),
+	}
+	config := tfsdk.Config{
+		Schema: s,
+		Raw: testObjectValue(t, ctx, s, map[string]attr.Value{
+			"cloud_provider": types.StringValue("AZURE"),
+			"instance_name":  types.StringValue("new"),
+		}),
+	}
+
+	// Attribute plan modifiers
+	for _, name := range []string{"tracking_guid", "manual_deployment_link"} {
+		applyStringPlanModifiers(t, ctx, s, name, config, &plan, state)
+	}
+
+	// Resource plan modification after the default tags are merged
+	var planModel models.CloudIntegrationTemplateModel
+	if diags := plan.Get(ctx, &planModel); diags.HasError() {
+		t.Fatalf("unexpected error reading plan: %v", diags)
+	}
+
+	req := resource.ModifyPlanRequest{Config: config, Plan: plan, State: state}
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.planTemplateRegeneration(ctx, req, resp, planModel)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var trackingGuid, manualDeploymentLink types.String
+	resp.Plan.GetAttribute(ctx, path.Root("tracking_guid"), &trackingGuid)
+	resp.Plan.GetAttribute(ctx, path.Root("manual_deployment_link"), &manualDeploymentLink)
+
+	if trackingGuid.ValueString() != "instance-guid" {
+		t.Errorf("expected tracking_guid to be kept from state, got %s", trackingGuid)
+	}
+
+	if !manualDeploymentLink.IsUnknown() {
+		t.Errorf("expected manual_deployment_link to be unknown, got %s", manualDeploymentLink)
+	}
+}
@@ -0,0 +1,417 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud_onboarding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mdboynton/cortex-cloud-go/cloudonboarding"
+	"github.com/mdboynton/cortex-cloud-go/enums"
+
+	models "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/cloud_onboarding"
+	providerModels "github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/models/provider"
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/planmodifiers"
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/util"
+	"github.com/PaloAltoNetworks/terraform-provider-cortexcloud/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &OutpostResource{}
+	_ resource.ResourceWithImportState = &OutpostResource{}
+)
+
+// NewOutpostResource is a helper function to simplify the provider implementation.
+func NewOutpostResource() resource.Resource {
+	return &OutpostResource{}
+}
+
+// OutpostResource is the resource implementation.
+type OutpostResource struct {
+	client *cloudonboarding.Client
+}
+
+// Metadata returns the resource type name.
+func (r *OutpostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_outpost"
+}
+
+// Schema defines the schema for the resource.
+func (r *OutpostResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an outpost, which runs scanning for cloud " +
+			"integrations on cloud infrastructure owned and managed by you. " +
+			"Creating this resource generates a template that must be " +
+			"deployed in the cloud provider to complete the outpost setup.",
+		Attributes: map[string]schema.Attribute{
+			"cloud_provider": schema.StringAttribute{
+				Description: "The cloud service provider in which the " +
+					"outpost will be deployed. Must be one of `AWS`, " +
+					"`AZURE` or `GCP`.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						enums.AllCloudProviders()...,
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"custom_resources_tags": schema.SetNestedAttribute{
+				Description: "Custom tags that will be applied to the " +
+					"resources created for the outpost in the cloud " +
+					"environment. Tag keys must be unique and satisfy the " +
+					"tag naming rules of the cloud provider.",
+				Optional: true,
+				Validators: []validator.Set{
+					validators.ValidateResourceTags(
+						[]string{models.ManagedByTagKey},
+						path.MatchRoot("cloud_provider"),
+					),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The key of the custom resource tag.",
+							Required:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The value of the custom resource tag.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"tracking_guid": schema.StringAttribute{
+				Description: "Tracking GUID of the outpost template, which " +
+					"is also the ID of the outpost. Use this value as the " +
+					"`outpost_id` of `cortexcloud_cloud_integration_template`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "The region in which the outpost is deployed. " +
+					"Populated once the template has been deployed.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the outpost. The outpost remains " +
+					"`PENDING` until the template is deployed in the cloud " +
+					"provider.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_time": schema.Int64Attribute{
+				Description: "Time the outpost was created, in " +
+					"milliseconds since the Unix epoch.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"automated_deployment_link": schema.StringAttribute{
+				Description: "Link to deploy the outpost template " +
+					"automatically in the cloud provider console.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.UseStateIfUnchangedString(path.MatchRoot("custom_resources_tags")),
+				},
+			},
+			"manual_deployment_link": schema.StringAttribute{
+				Description: "Link to download the outpost template for " +
+					"manual deployment.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.UseStateIfUnchangedString(path.MatchRoot("custom_resources_tags")),
+				},
+			},
+			"cloud_formation_template_url": schema.StringAttribute{
+				Description: "URL of the CloudFormation template. Only " +
+					"populated if `cloud_provider` is `AWS`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.UseStateIfUnchangedString(path.MatchRoot("custom_resources_tags")),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Delete: true,
+				DeleteDescription: "Maximum time to wait for the outpost " +
+					"to be removed after deleting it. Defaults to `10m`.",
+			}),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *OutpostResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerModels.CortexCloudSDKClients)
+
+	if !ok {
+		util.AddUnexpectedResourceConfigureTypeError(&resp.Diagnostics, "*http.Client", req.ProviderData)
+		return
+	}
+
+	r.client = client.CloudOnboarding
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *OutpostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer util.PanicHandler(&resp.Diagnostics)
+
+	// Read Terraform plan data into model
+	var plan models.OutpostModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	request := plan.ToCreateRequest(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create new outpost template
+	response, err := r.client.CreateOutpostTemplate(ctx, request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Outpost Create Error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Populate API response values into model
+	plan.RefreshComputedPropertyValues(&resp.Diagnostics, response)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *OutpostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer util.PanicHandler(&resp.Diagnostics)
+
+	// Get current state
+	var state models.OutpostModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve outpost details from API
+	request := state.ToGetRequest(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.ListOutposts(ctx, request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Outpost Read Error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Remove resource from state if the outpost no longer exists
+	if len(response.Reply.Data) == 0 {
+		tflog.Warn(ctx, "Outpost not found, removing from state", map[string]any{
+			"tracking_guid": state.TrackingGuid.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Refresh state values
+	state.RefreshPropertyValues(ctx, &resp.Diagnostics, response.Reply.Data[0])
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *OutpostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer util.PanicHandler(&resp.Diagnostics)
+
+	// Read Terraform plan data into model
+	var plan models.OutpostModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	request := plan.ToUpdateRequest(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update outpost
+	response, err := r.client.EditOutpost(ctx, request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Outpost Update Error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Refresh state values
+	plan.RefreshComputedPropertyValues(&resp.Diagnostics, response)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to updated values
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes it from the Terraform state on success.
+func (r *OutpostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer util.PanicHandler(&resp.Diagnostics)
+
+	// Get current state
+	var state models.OutpostModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete outpost
+	if err := r.client.DeleteOutposts(ctx, state.ToDeleteRequest(ctx, &resp.Diagnostics)); err != nil {
+		resp.Diagnostics.AddError(
+			"Outpost Delete Error",
+			err.Error(),
+		)
+		return
+	}
+
+	// Wait for the outpost to be removed
+	timeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.waitForDeletion(ctx, state.TrackingGuid.ValueString(), timeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Outpost Delete Error",
+			err.Error(),
+		)
+	}
+}
+
+// waitForDeletion polls the API until the outpost with the given ID is no
+// longer returned, or the timeout elapses.
+func (r *OutpostResource) waitForDeletion(ctx context.Context, outpostId string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request := models.ToOutpostLookupRequest(outpostId)
+	for {
+		response, err := r.client.ListOutposts(ctx, request)
+		if err != nil {
+			return fmt.Errorf("error checking deletion status of outpost %s: %w", outpostId, err)
+		}
+
+		if len(response.Reply.Data) == 0 {
+			return nil
+		}
+
+		tflog.Debug(ctx, "Waiting for outpost deletion", map[string]any{
+			"tracking_guid": outpostId,
+			"status":        response.Reply.Data[0].Status,
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for outpost %s to be deleted (last status: %s)", outpostId, response.Reply.Data[0].Status)
+		case <-time.After(deletePollInterval):
+		}
+	}
+}
+
+// ImportState imports an existing outpost by ID.
+//
+// The API only returns the deployment links when an outpost template is
+// created or edited, so they are left null on import. They remain null
+// until the template is regenerated by a change to custom_resources_tags.
+func (r *OutpostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	defer util.PanicHandler(&resp.Diagnostics)
+
+	// Retrieve outpost details from API
+	response, err := r.client.ListOutposts(ctx, models.ToOutpostLookupRequest(req.ID))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Outpost Import Error",
+			err.Error(),
+		)
+		return
+	}
+
+	if len(response.Reply.Data) == 0 {
+		resp.Diagnostics.AddError(
+			"Outpost Not Found",
+			fmt.Sprintf("No outpost was found with ID %q.", req.ID),
+		)
+		return
+	}
+
+	// Initialize state with the outpost ID so that the remaining
+	// attributes are populated as typed null values
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tracking_guid"), response.Reply.Data[0].OutpostId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state models.OutpostModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Populate API response values into model
+	state.RefreshPropertyValues(ctx, &resp.Diagnostics, response.Reply.Data[0])
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set imported state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ validator.Int32 = ConflictsWithOnStringValuesValidator{}
+)
+
+// ConflictsWithOnStringValuesValidator validates that an attribute is not
+// configured if the String attribute matched by PathExpression is set to
+// one of OnValues.
+type ConflictsWithOnStringValuesValidator struct {
+	OnValues       []string
+	PathExpression path.Expression
+}
+
+// ConflictsWithOnStringValues checks that the attribute is not configured
+// if the String attribute matched by expression is set to one of onValues.
+//
+// Relative path.Expression will be resolved using the attribute being
+// validated.
+func ConflictsWithOnStringValues(onValues []string, expression path.Expression) validator.Int32 {
+	return ConflictsWithOnStringValuesValidator{
+		OnValues:       onValues,
+		PathExpression: expression,
+	}
+}
+
+func (v ConflictsWithOnStringValuesValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Cannot be configured if %s is one of: %s", v.PathExpression, strings.Join(v.OnValues, ", "))
+}
+
+func (v ConflictsWithOnStringValuesValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+// ValidateInt32 implements validator.Int32.
+func (v ConflictsWithOnStringValuesValidator) ValidateInt32(ctx context.Context, req validator.Int32Request, resp *validator.Int32Response) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	matchedPaths, diags := req.Config.PathMatches(ctx, req.PathExpression.Merge(v.PathExpression))
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	for _, mp := range matchedPaths {
+		var mpVal types.String
+		diags := req.Config.GetAttribute(ctx, mp, &mpVal)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			continue
+		}
+
+		// Delay validation until the attribute has a known value
+		if mpVal.IsNull() || mpVal.IsUnknown() {
+			continue
+		}
+
+		if slices.Contains(v.OnValues, mpVal.ValueString()) {
+			resp.Diagnostics.Append(validatordiag.InvalidAttributeCombinationDiagnostic(
+				req.Path,
+				fmt.Sprintf("Attribute %q cannot be configured when %q is %q.", req.Path, mp, mpVal.ValueString()),
+			))
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ validator.Bool = EnabledOnlyOnStringValuesValidator{}
+)
+
+// EnabledOnlyOnStringValuesValidator validates that a Bool attribute is
+// only set to true if the String attribute matched by PathExpression is set
+// to one of OnValues.
+type EnabledOnlyOnStringValuesValidator struct {
+	OnValues       []string
+	PathExpression path.Expression
+}
+
+// EnabledOnlyOnStringValues checks that the attribute is only set to true
+// if the String attribute matched by expression is set to one of onValues.
+//
+// Relative path.Expression will be resolved using the attribute being
+// validated.
+func EnabledOnlyOnStringValues(onValues []string, expression path.Expression) validator.Bool {
+	return EnabledOnlyOnStringValuesValidator{
+		OnValues:       onValues,
+		PathExpression: expression,
+	}
+}
+
+func (v EnabledOnlyOnStringValuesValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Can only be enabled if %s is one of: %s", v.PathExpression, strings.Join(v.OnValues, ", "))
+}
+
+func (v EnabledOnlyOnStringValuesValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+// ValidateBool implements validator.Bool.
+func (v EnabledOnlyOnStringValuesValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	// Only enabling the attribute is restricted
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || !req.ConfigValue.ValueBool() {
+		return
+	}
+
+	matchedPaths, diags := req.Config.PathMatches(ctx, req.PathExpression.Merge(v.PathExpression))
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	for _, mp := range matchedPaths {
+		var mpVal types.String
+		diags := req.Config.GetAttribute(ctx, mp, &mpVal)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			continue
+		}
+
+		// Delay validation until the attribute has a known value
+		if mpVal.IsNull() || mpVal.IsUnknown() {
+			continue
+		}
+
+		if !slices.Contains(v.OnValues, mpVal.ValueString()) {
+			resp.Diagnostics.Append(validatordiag.InvalidAttributeCombinationDiagnostic(
+				req.Path,
+				fmt.Sprintf("Attribute %q cannot be enabled when %q is %q. Supported values: %s", req.Path, mp, mpVal.ValueString(), strings.Join(v.OnValues, ", ")),
+			))
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mdboynton/cortex-cloud-go/enums"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ validator.Set = ResourceTagsValidator{}
+)
+
+// tagRules contains the restrictions a cloud service provider places on the
+// keys and values of resource tags.
+type tagRules struct {
+	MaxKeyLength       int
+	MaxValueLength     int
+	KeyPattern         *regexp.Regexp
+	ValuePattern       *regexp.Regexp
+	CharsetDescription string
+	ReservedKeyPrefix  string
+}
+
+// cloudProviderTagRules maps each cloud service provider to its tag rules.
+// GCP resources are tagged with labels, which are more restrictive than AWS
+// and Azure tags.
+var cloudProviderTagRules = map[string]tagRules{
+	enums.CloudProviderAWS.String(): {
+		MaxKeyLength:       128,
+		MaxValueLength:     256,
+		KeyPattern:         regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]+$`),
+		ValuePattern:       regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`),
+		CharsetDescription: "letters, numbers, spaces and the characters _ . : / = + - @",
+		ReservedKeyPrefix:  "aws:",
+	},
+	enums.CloudProviderAzure.String(): {
+		MaxKeyLength:       512,
+		MaxValueLength:     256,
+		KeyPattern:         regexp.MustCompile(`^[^<>%&\\?/]+$`),
+		ValuePattern:       regexp.MustCompile(`^.*$`),
+		CharsetDescription: "any characters except < > % & \\ ? / in keys",
+	},
+	enums.CloudProviderGCP.String(): {
+		MaxKeyLength:       63,
+		MaxValueLength:     63,
+		KeyPattern:         regexp.MustCompile(`^[\p{Ll}\p{Lo}][\p{Ll}\p{Lo}\p{N}_-]*$`),
+		ValuePattern:       regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]*$`),
+		CharsetDescription: "lowercase letters, numbers, underscores and dashes, with keys starting with a lowercase letter",
+	},
+}
+
+// ResourceTagsValidator validates a set of key/value tag objects.
+type ResourceTagsValidator struct {
+	ReservedKeys            []string
+	CloudProviderExpression path.Expression
+}
+
+// ValidateResourceTags checks that the keys of a set of key/value tag
+// objects are unique and not one of reservedKeys, and that the keys and
+// values satisfy the tag rules of the cloud service provider matched by
+// cloudProviderExpression.
+//
+// Relative path.Expression will be resolved using the attribute being
+// validated.
+func ValidateResourceTags(reservedKeys []string, cloudProviderExpression path.Expression) validator.Set {
+	return ResourceTagsValidator{
+		ReservedKeys:            reservedKeys,
+		CloudProviderExpression: cloudProviderExpression,
+	}
+}
+
+func (v ResourceTagsValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Tag keys must be unique, cannot be one of [%s] and must satisfy the tag rules of the cloud provider", strings.Join(v.ReservedKeys, ", "))
+}
+
+func (v ResourceTagsValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+// ValidateSet implements validator.Set.
+func (v ResourceTagsValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	rules, hasRules := v.cloudProviderRules(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seenKeys := map[string]bool{}
+	for _, element := range req.ConfigValue.Elements() {
+		tag, ok := element.(types.Object)
+		if !ok || tag.IsNull() || tag.IsUnknown() {
+			continue
+		}
+
+		keyPath := req.Path.AtSetValue(element).AtName("key")
+		valuePath := req.Path.AtSetValue(element).AtName("value")
+
+		key, keyOk := tag.Attributes()["key"].(types.String)
+		if !keyOk || key.IsNull() || key.IsUnknown() {
+			continue
+		}
+
+		if seenKeys[key.ValueString()] {
+			resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+				keyPath,
+				"tag keys must be unique",
+				key.ValueString(),
+			))
+		}
+		seenKeys[key.ValueString()] = true
+
+		validateReservedKey(&resp.Diagnostics, keyPath, key.ValueString(), v.ReservedKeys)
+
+		if !hasRules {
+			continue
+		}
+
+		rules.validateKey(&resp.Diagnostics, keyPath, key.ValueString())
+
+		value, valueOk := tag.Attributes()["value"].(types.String)
+		if valueOk && !value.IsNull() && !value.IsUnknown() {
+			rules.validateValue(&resp.Diagnostics, valuePath, value.ValueString())
+		}
+	}
+}
+
+// ValidateTagMap checks that the keys of a map of tag keys to tag values
+// are not one of reservedKeys and, if cloudProvider is a known cloud service
+// provider, that the keys and values satisfy its tag rules. The returned
+// diagnostics are reported on the elements of tagsPath.
+func ValidateTagMap(tagsPath path.Path, tags map[string]string, reservedKeys []string, cloudProvider string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	rules, hasRules := cloudProviderTagRules[cloudProvider]
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		tagPath := tagsPath.AtMapKey(key)
+
+		validateReservedKey(&diagnostics, tagPath, key, reservedKeys)
+
+		if !hasRules {
+			continue
+		}
+
+		rules.validateKey(&diagnostics, tagPath, key)
+		rules.validateValue(&diagnostics, tagPath, tags[key])
+	}
+
+	return diagnostics
+}
+
+func validateReservedKey(diagnostics *diag.Diagnostics, p path.Path, key string, reservedKeys []string) {
+	if slices.Contains(reservedKeys, key) {
+		diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			p,
+			"tag key is reserved and always applied by Cortex Cloud",
+			key,
+		))
+	}
+}
+
+// cloudProviderRules returns the tag rules of the configured cloud service
+// provider, if it is known.
+func (v ResourceTagsValidator) cloudProviderRules(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) (tagRules, bool) {
+	matchedPaths, diags := req.Config.PathMatches(ctx, req.PathExpression.Merge(v.CloudProviderExpression))
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || len(matchedPaths) == 0 {
+		return tagRules{}, false
+	}
+
+	var cloudProvider types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, matchedPaths[0], &cloudProvider)...)
+	if resp.Diagnostics.HasError() || cloudProvider.IsNull() || cloudProvider.IsUnknown() {
+		return tagRules{}, false
+	}
+
+	rules, ok := cloudProviderTagRules[cloudProvider.ValueString()]
+	return rules, ok
+}
+
+func (r tagRules) validateKey(diagnostics *diag.Diagnostics, p path.Path, key string) {
+	if length := utf8.RuneCountInString(key); length == 0 || length > r.MaxKeyLength {
+		diagnostics.Append(validatordiag.InvalidAttributeValueLengthDiagnostic(
+			p,
+			fmt.Sprintf("must be between 1 and %d characters", r.MaxKeyLength),
+			key,
+		))
+	}
+
+	if !r.KeyPattern.MatchString(key) {
+		diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+			p,
+			fmt.Sprintf("tag keys may only contain %s", r.CharsetDescription),
+			key,
+		))
+	}
+
+	if r.ReservedKeyPrefix != "" && strings.HasPrefix(strings.ToLower(key), r.ReservedKeyPrefix) {
+		diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			p,
+			fmt.Sprintf("tag keys cannot begin with %q", r.ReservedKeyPrefix),
+			key,
+		))
+	}
+}
+
+func (r tagRules) validateValue(diagnostics *diag.Diagnostics, p path.Path, value string) {
+	if utf8.RuneCountInString(value) > r.MaxValueLength {
+		diagnostics.Append(validatordiag.InvalidAttributeValueLengthDiagnostic(
+			p,
+			fmt.Sprintf("must be at most %d characters", r.MaxValueLength),
+			value,
+		))
+	}
+
+	if !r.ValuePattern.MatchString(value) {
+		diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+			p,
+			fmt.Sprintf("tag values may only contain %s", r.CharsetDescription),
+			value,
+		))
+	}
+}
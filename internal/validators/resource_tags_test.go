@@ -0,0 +1,202 @@
+// Copyright (c) Palo Alto Networks, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var testTagObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"key":   types.StringType,
+		"value": types.StringType,
+	},
+}
+
+func testTagSet(t *testing.T, tags ...[2]string) types.Set {
+	t.Helper()
+
+	elements := make([]attr.Value, 0, len(tags))
+	for _, tag := range tags {
+		elements = append(elements, types.ObjectValueMust(testTagObjectType.AttrTypes, map[string]attr.Value{
+			"key":   types.StringValue(tag[0]),
+			"value": types.StringValue(tag[1]),
+		}))
+	}
+
+	return types.SetValueMust(testTagObjectType, elements)
+}
+
+func testTagsConfig(cloudProvider string) tfsdk.Config {
+	cloudProviderValue := tftypes.NewValue(tftypes.String, nil)
+	if cloudProvider != "" {
+		cloudProviderValue = tftypes.NewValue(tftypes.String, cloudProvider)
+	}
+
+	return tfsdk.Config{
+		Schema: schema.Schema{
+			Attributes: map[string]schema.Attribute{
+				"cloud_provider": schema.StringAttribute{
+					Optional: true,
+				},
+			},
+		},
+		Raw: tftypes.NewValue(
+			tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"cloud_provider": tftypes.String,
+				},
+			},
+			map[string]tftypes.Value{
+				"cloud_provider": cloudProviderValue,
+			},
+		),
+	}
+}
+
+func TestValidateResourceTags(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cloudProvider  string
+		tags           types.Set
+		expectedErrors []string
+	}{
+		"null": {
+			cloudProvider: "AWS",
+			tags:          types.SetNull(testTagObjectType),
+		},
+		"unknown": {
+			cloudProvider: "AWS",
+			tags:          types.SetUnknown(testTagObjectType),
+		},
+		"valid-aws": {
+			cloudProvider: "AWS",
+			tags:          testTagSet(t, [2]string{"team", "platform"}, [2]string{"cost-center", "1234"}),
+		},
+		"reserved-key": {
+			cloudProvider:  "AWS",
+			tags:           testTagSet(t, [2]string{"managed_by", "me"}),
+			expectedErrors: []string{"tag key is reserved"},
+		},
+		"reserved-key-unknown-cloud-provider": {
+			tags:           testTagSet(t, [2]string{"managed_by", "me"}),
+			expectedErrors: []string{"tag key is reserved"},
+		},
+		"aws-reserved-prefix": {
+			cloudProvider:  "AWS",
+			tags:           testTagSet(t, [2]string{"AWS:owner", "me"}),
+			expectedErrors: []string{`tag keys cannot begin with "aws:"`},
+		},
+		"aws-invalid-characters": {
+			cloudProvider:  "AWS",
+			tags:           testTagSet(t, [2]string{"owner#1", "me"}),
+			expectedErrors: []string{"tag keys may only contain"},
+		},
+		"azure-invalid-key-characters": {
+			cloudProvider:  "AZURE",
+			tags:           testTagSet(t, [2]string{"owner/name", "me"}),
+			expectedErrors: []string{"tag keys may only contain"},
+		},
+		"gcp-uppercase-key": {
+			cloudProvider:  "GCP",
+			tags:           testTagSet(t, [2]string{"Team", "platform"}),
+			expectedErrors: []string{"tag keys may only contain"},
+		},
+		"gcp-value-too-long": {
+			cloudProvider:  "GCP",
+			tags:           testTagSet(t, [2]string{"team", strings.Repeat("a", 64)}),
+			expectedErrors: []string{"must be at most 63 characters"},
+		},
+		"invalid-characters-unknown-cloud-provider": {
+			tags: testTagSet(t, [2]string{"Team#1", "platform"}),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.SetRequest{
+				Path:           path.Root("custom_resources_tags"),
+				PathExpression: path.MatchRoot("custom_resources_tags"),
+				ConfigValue:    testCase.tags,
+				Config:         testTagsConfig(testCase.cloudProvider),
+			}
+			resp := &validator.SetResponse{}
+
+			ValidateResourceTags([]string{"managed_by"}, path.MatchRoot("cloud_provider")).ValidateSet(context.Background(), req, resp)
+
+			errors := resp.Diagnostics.Errors()
+			if len(errors) != len(testCase.expectedErrors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(testCase.expectedErrors), len(errors), errors)
+			}
+
+			for i, expected := range testCase.expectedErrors {
+				if !strings.Contains(errors[i].Detail(), expected) {
+					t.Errorf("expected error %d to contain %q, got %q", i, expected, errors[i].Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTagMap(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cloudProvider  string
+		tags           map[string]string
+		expectedErrors []string
+	}{
+		"empty": {
+			tags: map[string]string{},
+		},
+		"valid-any-cloud-provider": {
+			tags: map[string]string{"Team#1": "platform"},
+		},
+		"reserved-key": {
+			tags:           map[string]string{"managed_by": "me"},
+			expectedErrors: []string{"tag key is reserved"},
+		},
+		"valid-gcp": {
+			cloudProvider: "GCP",
+			tags:          map[string]string{"team": "platform"},
+		},
+		"invalid-gcp": {
+			cloudProvider:  "GCP",
+			tags:           map[string]string{"Team": "Platform"},
+			expectedErrors: []string{"tag keys may only contain", "tag values may only contain"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := ValidateTagMap(path.Root("tags"), testCase.tags, []string{"managed_by"}, testCase.cloudProvider)
+
+			errors := diags.Errors()
+			if len(errors) != len(testCase.expectedErrors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(testCase.expectedErrors), len(errors), errors)
+			}
+
+			for i, expected := range testCase.expectedErrors {
+				if !strings.Contains(errors[i].Detail(), expected) {
+					t.Errorf("expected error %d to contain %q, got %q", i, expected, errors[i].Detail())
+				}
+			}
+		})
+	}
+}